@@ -5,16 +5,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
 	"github.com/sirupsen/logrus"
 )
 
+// ErrRunNotActive is returned by CancelRun when no stream for the run is in
+// flight and there is no CancelEndpoint to notify
+var ErrRunNotActive = errors.New("run is not active")
+
 type Config struct {
 	Endpoint       string
 	APIKey         string
@@ -24,12 +32,33 @@ type Config struct {
 	ReadTimeout    time.Duration
 	BufferSize     int
 	Logger         *logrus.Logger
+
+	// CancelEndpoint, when set, is the URL CancelRun POSTs to so the server
+	// can abort the run. The request body is {"threadId":...,"runId":...}.
+	CancelEndpoint string
+
+	// MaxEventBytes limits the size of a single SSE event. The stream is
+	// stopped with an error wrapping encoding.ErrEventTooLarge as soon as an
+	// event grows past it, without buffering the rest. Zero uses
+	// encoding.DefaultMaxEventBytes; a negative value removes the limit.
+	MaxEventBytes int64
 }
 
 type Client struct {
 	config     Config
 	httpClient *http.Client
 	logger     *logrus.Logger
+
+	mu   sync.Mutex
+	runs map[string]*activeRun
+
+	stats clientStats
+}
+
+// activeRun tracks an in-flight stream so CancelRun can stop it
+type activeRun struct {
+	threadID string
+	cancel   context.CancelFunc
 }
 
 type Frame struct {
@@ -60,6 +89,10 @@ func NewClient(config Config) *Client {
 		config.BufferSize = 100
 	}
 
+	if config.MaxEventBytes == 0 {
+		config.MaxEventBytes = encoding.DefaultMaxEventBytes
+	}
+
 	transport := &http.Transport{
 		DisableCompression:    true,
 		ExpectContinueTimeout: 0,
@@ -80,6 +113,7 @@ func NewClient(config Config) *Client {
 		config:     config,
 		httpClient: httpClient,
 		logger:     config.Logger,
+		runs:       make(map[string]*activeRun),
 	}
 }
 
@@ -96,11 +130,34 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 
 	payloadBytes, err := json.Marshal(opts.Payload)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal payload: %w", err)
+		return nil, nil, &EncodeError{Err: err}
+	}
+
+	// Derive a per-stream context so CancelRun can stop delivery for this run
+	// without affecting the caller's context.
+	ctx, cancel := context.WithCancel(opts.Context)
+	run := c.trackRun(opts.Payload.ThreadID, opts.Payload.RunID, cancel)
+
+	done := func() {
+		c.untrackRun(opts.Payload.RunID, run)
+		cancel()
+	}
+
+	frames, errors, err := c.startStream(ctx, opts, payloadBytes, done)
+	if err != nil {
+		c.stats.streamFailures.Add(1)
+		done()
+		return nil, nil, err
 	}
 
+	return frames, errors, nil
+}
+
+// startStream issues the request and starts reading the response stream,
+// calling done once the stream has been fully read
+func (c *Client) startStream(ctx context.Context, opts StreamOptions, payloadBytes []byte, done func()) (<-chan Frame, <-chan error, error) {
 	req, err := http.NewRequestWithContext(
-		opts.Context,
+		ctx,
 		http.MethodPost,
 		c.config.Endpoint,
 		bytes.NewReader(payloadBytes),
@@ -114,25 +171,7 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
 
-	if c.config.APIKey != "" {
-		authHeader := c.config.AuthHeader
-		if authHeader == "" {
-			authHeader = "Authorization"
-		}
-
-		// Build the header value based on header type
-		if authHeader == "Authorization" {
-			// Use scheme (Bearer by default) for Authorization header
-			scheme := "Bearer"
-			if c.config.AuthScheme != "" {
-				scheme = c.config.AuthScheme
-			}
-			req.Header.Set(authHeader, scheme+" "+c.config.APIKey)
-		} else {
-			// For custom headers like X-API-Key, use the key directly
-			req.Header.Set(authHeader, c.config.APIKey)
-		}
-	}
+	c.setAuthHeader(req)
 
 	for key, value := range opts.Headers {
 		req.Header.Set(key, value)
@@ -146,10 +185,21 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 		}).Debug("Initiating SSE connection")
 	}
 
+	sentAt := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+		var netErr net.Error
+		switch {
+		case ctx.Err() != nil:
+			return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return nil, nil, fmt.Errorf("failed to execute request: %w: %w", ErrTimeout, err)
+		default:
+			return nil, nil, fmt.Errorf("failed to execute request: %w: %w", ErrNotConnected, err)
+		}
 	}
+	c.stats.bytesSent.Add(int64(len(payloadBytes)))
+	c.stats.observeHeaderLatency(time.Since(sentAt))
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -170,14 +220,149 @@ func (c *Client) stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
 		}).Info("SSE connection established")
 	}
 
+	c.stats.streamsOpened.Add(1)
+	if req.Header.Get("Last-Event-ID") != "" {
+		c.stats.resumedStreams.Add(1)
+	}
+	c.stats.activeStreams.Add(1)
+
 	frames := make(chan Frame, c.config.BufferSize)
 	errors := make(chan error, 1)
 
-	go c.readStream(opts.Context, resp, frames, errors)
+	go func() {
+		defer c.stats.activeStreams.Add(-1)
+		defer done()
+		c.readStream(ctx, resp, frames, errors)
+	}()
 
 	return frames, errors, nil
 }
 
+// setAuthHeader adds the configured API key to the request
+func (c *Client) setAuthHeader(req *http.Request) {
+	if c.config.APIKey != "" {
+		authHeader := c.config.AuthHeader
+		if authHeader == "" {
+			authHeader = "Authorization"
+		}
+
+		// Build the header value based on header type
+		if authHeader == "Authorization" {
+			// Use scheme (Bearer by default) for Authorization header
+			scheme := "Bearer"
+			if c.config.AuthScheme != "" {
+				scheme = c.config.AuthScheme
+			}
+			req.Header.Set(authHeader, scheme+" "+c.config.APIKey)
+		} else {
+			// For custom headers like X-API-Key, use the key directly
+			req.Header.Set(authHeader, c.config.APIKey)
+		}
+	}
+}
+
+// trackRun registers an in-flight stream under its run ID. Streams without a
+// run ID are not tracked and cannot be cancelled by ID.
+func (c *Client) trackRun(threadID, runID string, cancel context.CancelFunc) *activeRun {
+	if runID == "" {
+		return nil
+	}
+
+	run := &activeRun{threadID: threadID, cancel: cancel}
+
+	c.mu.Lock()
+	c.runs[runID] = run
+	c.mu.Unlock()
+
+	return run
+}
+
+// untrackRun removes run from the registry unless a newer stream for the same
+// run ID has replaced it
+func (c *Client) untrackRun(runID string, run *activeRun) {
+	if run == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if c.runs[runID] == run {
+		delete(c.runs, runID)
+	}
+	c.mu.Unlock()
+}
+
+// CancelRun stops local delivery for an in-flight run and, when
+// Config.CancelEndpoint is set, asks the server to abort it. Local delivery
+// stops even if the server request fails: the run's frames and errors
+// channels are closed without a terminal event. Without a CancelEndpoint,
+// cancelling a run that is not streaming returns ErrRunNotActive.
+func (c *Client) CancelRun(ctx context.Context, runID string) error {
+	if runID == "" {
+		return fmt.Errorf("run ID is required")
+	}
+
+	c.mu.Lock()
+	run, active := c.runs[runID]
+	if active {
+		delete(c.runs, runID)
+	}
+	c.mu.Unlock()
+
+	threadID := ""
+	if active {
+		run.cancel()
+		threadID = run.threadID
+		if c.logger != nil {
+			c.logger.WithField("run_id", runID).Debug("Cancelled SSE stream")
+		}
+	}
+
+	if c.config.CancelEndpoint == "" {
+		if !active {
+			return fmt.Errorf("%w: %s", ErrRunNotActive, runID)
+		}
+		return nil
+	}
+
+	return c.requestCancel(ctx, threadID, runID)
+}
+
+// requestCancel POSTs a cancellation request for the run to CancelEndpoint
+func (c *Client) requestCancel(ctx context.Context, threadID, runID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"threadId": threadID,
+		"runId":    runID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cancel request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.CancelEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create cancel request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	c.setAuthHeader(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute cancel request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("cancel request failed with status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
 func (c *Client) readStream(ctx context.Context, resp *http.Response, frames chan<- Frame, errors chan<- error) {
 	defer func() {
 		_ = resp.Body.Close()
@@ -213,7 +398,7 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 
 		// Start async read
 		go func() {
-			line, err := reader.ReadBytes('\n')
+			line, err := readLine(reader, c.config.MaxEventBytes)
 			select {
 			case readCh <- readResult{line: line, err: err}:
 			case <-ctx.Done():
@@ -229,7 +414,7 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 			case <-time.After(c.config.ReadTimeout):
 				// Timeout occurred
 				select {
-				case errors <- fmt.Errorf("read timeout after %v", c.config.ReadTimeout):
+				case errors <- fmt.Errorf("%w: no data received for %v", ErrTimeout, c.config.ReadTimeout):
 				case <-ctx.Done():
 				}
 				return
@@ -266,6 +451,7 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 		line := result.line
 
 		byteCount += int64(len(line))
+		c.stats.bytesReceived.Add(int64(len(line)))
 		line = bytes.TrimSuffix(line, []byte("\n"))
 		line = bytes.TrimSuffix(line, []byte("\r"))
 
@@ -281,6 +467,7 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 				select {
 				case frames <- frame:
 					frameCount++
+					c.stats.framesReceived.Add(1)
 					if frameCount%100 == 0 && c.logger != nil {
 						c.logger.WithFields(logrus.Fields{
 							"frames": frameCount,
@@ -290,6 +477,15 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 				case <-ctx.Done():
 					return
 				}
+
+				// RUN_ERROR is terminal, so end the stream with a typed error
+				if runErr := runErrorFromFrame(frame.Data); runErr != nil {
+					select {
+					case errors <- runErr:
+					case <-ctx.Done():
+					}
+					return
+				}
 			}
 			continue
 		}
@@ -300,6 +496,31 @@ func (c *Client) readStream(ctx context.Context, resp *http.Response, frames cha
 				buffer.WriteByte('\n')
 			}
 			buffer.Write(data)
+			if c.config.MaxEventBytes > 0 && int64(buffer.Len()) > c.config.MaxEventBytes {
+				select {
+				case errors <- fmt.Errorf("event exceeds max size of %d bytes: %w", c.config.MaxEventBytes, encoding.ErrEventTooLarge):
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}
+}
+
+// readLine reads up to and including the next newline, failing with
+// encoding.ErrEventTooLarge once the line exceeds limit bytes so an
+// oversized line is never buffered in full. A limit of zero or less reads
+// lines of any length.
+func readLine(reader *bufio.Reader, limit int64) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if limit > 0 && int64(len(line)+len(chunk)) > limit {
+			return nil, fmt.Errorf("line exceeds max event size of %d bytes: %w", limit, encoding.ErrEventTooLarge)
+		}
+		line = append(line, chunk...)
+		if err != bufio.ErrBufferFull {
+			return line, err
 		}
 	}
 }
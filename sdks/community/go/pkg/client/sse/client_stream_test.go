@@ -3,15 +3,19 @@ package sse
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -675,6 +679,284 @@ func TestReadStreamWithErrors(t *testing.T) {
 	})
 }
 
+func TestCancelRun(t *testing.T) {
+	// newStreamingServer streams frames until the client goes away
+	newStreamingServer := func(t *testing.T) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			flusher, ok := w.(http.Flusher)
+			require.True(t, ok)
+
+			for {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(10 * time.Millisecond):
+					fmt.Fprintf(w, "data: tick\n\n")
+					flusher.Flush()
+				}
+			}
+		}))
+	}
+
+	drain := func(t *testing.T, frames <-chan Frame) {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case _, ok := <-frames:
+				if !ok {
+					return
+				}
+			case <-timeout:
+				require.FailNow(t, "frames channel was not closed after cancel")
+			}
+		}
+	}
+
+	t.Run("stops local delivery", func(t *testing.T) {
+		server := newStreamingServer(t)
+		defer server.Close()
+
+		client := NewClient(Config{Endpoint: server.URL})
+		frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		<-frames
+		require.NoError(t, client.CancelRun(context.Background(), "run-1"))
+		drain(t, frames)
+
+		err = client.CancelRun(context.Background(), "run-1")
+		assert.ErrorIs(t, err, ErrRunNotActive)
+	})
+
+	t.Run("notifies the cancel endpoint", func(t *testing.T) {
+		server := newStreamingServer(t)
+		defer server.Close()
+
+		type cancelRequest struct {
+			body map[string]string
+			auth string
+		}
+		received := make(chan cancelRequest, 1)
+		cancelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received <- cancelRequest{body: body, auth: r.Header.Get("Authorization")}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer cancelServer.Close()
+
+		client := NewClient(Config{
+			Endpoint:       server.URL,
+			CancelEndpoint: cancelServer.URL,
+			APIKey:         "secret",
+		})
+		frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		<-frames
+		require.NoError(t, client.CancelRun(context.Background(), "run-1"))
+		drain(t, frames)
+
+		req := <-received
+		assert.Equal(t, map[string]string{"threadId": "thread-1", "runId": "run-1"}, req.body)
+		assert.Equal(t, "Bearer secret", req.auth)
+	})
+
+	t.Run("reports cancel endpoint failures", func(t *testing.T) {
+		server := newStreamingServer(t)
+		defer server.Close()
+
+		cancelServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "no such run", http.StatusNotFound)
+		}))
+		defer cancelServer.Close()
+
+		client := NewClient(Config{Endpoint: server.URL, CancelEndpoint: cancelServer.URL})
+		frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		<-frames
+		err = client.CancelRun(context.Background(), "run-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+
+		// Local delivery stops regardless of the server response
+		drain(t, frames)
+	})
+
+	t.Run("finished streams are untracked", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "data: only\n\n")
+		}))
+		defer server.Close()
+
+		client := NewClient(Config{Endpoint: server.URL})
+		frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+		drain(t, frames)
+
+		assert.Eventually(t, func() bool {
+			return errors.Is(client.CancelRun(context.Background(), "run-1"), ErrRunNotActive)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("requires a run ID", func(t *testing.T) {
+		client := NewClient(Config{})
+		assert.Error(t, client.CancelRun(context.Background(), ""))
+	})
+}
+
+func TestMaxEventBytes(t *testing.T) {
+	newServer := func(body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, body)
+		}))
+	}
+
+	collect := func(t *testing.T, client *Client) ([]Frame, error) {
+		t.Helper()
+		frames, errs, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		var received []Frame
+		for frame := range frames {
+			received = append(received, frame)
+		}
+		return received, <-errs
+	}
+
+	t.Run("oversized line", func(t *testing.T) {
+		server := newServer("data: small\n\ndata: " + strings.Repeat("x", 64*1024) + "\n\n")
+		defer server.Close()
+
+		frames, err := collect(t, NewClient(Config{Endpoint: server.URL, MaxEventBytes: 1024}))
+		require.Len(t, frames, 1)
+		assert.Equal(t, "small", string(frames[0].Data))
+		assert.ErrorIs(t, err, encoding.ErrEventTooLarge)
+	})
+
+	t.Run("oversized multi-line event", func(t *testing.T) {
+		line := "data: " + strings.Repeat("y", 600) + "\n"
+		server := newServer(line + line + "\n")
+		defer server.Close()
+
+		frames, err := collect(t, NewClient(Config{Endpoint: server.URL, MaxEventBytes: 1024}))
+		assert.Empty(t, frames)
+		assert.ErrorIs(t, err, encoding.ErrEventTooLarge)
+	})
+
+	t.Run("default and unlimited", func(t *testing.T) {
+		assert.Equal(t, int64(encoding.DefaultMaxEventBytes), NewClient(Config{}).config.MaxEventBytes)
+
+		server := newServer("data: " + strings.Repeat("z", 64*1024) + "\n\n")
+		defer server.Close()
+
+		frames, err := collect(t, NewClient(Config{Endpoint: server.URL, MaxEventBytes: -1}))
+		require.Len(t, frames, 1)
+		assert.Len(t, frames[0].Data, 64*1024)
+		assert.NoError(t, err)
+	})
+}
+
+func TestClientStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Fail") != "" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "data: one\n\n")
+		fmt.Fprintf(w, ": keepalive\n\n")
+		fmt.Fprintf(w, "data: two\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL})
+	assert.Equal(t, ConnectionStats{}, client.Stats())
+
+	frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+	require.NoError(t, err)
+	for range frames {
+	}
+
+	frames, _, err = client.Stream(StreamOptions{
+		Payload: newTestRunAgentInput(),
+		Headers: map[string]string{"Last-Event-ID": "run-1:0"},
+	})
+	require.NoError(t, err)
+	for range frames {
+	}
+
+	_, _, err = client.Stream(StreamOptions{
+		Payload: newTestRunAgentInput(),
+		Headers: map[string]string{"X-Fail": "1"},
+	})
+	require.Error(t, err)
+
+	require.Eventually(t, func() bool {
+		return client.Stats().ActiveStreams == 0
+	}, time.Second, 10*time.Millisecond)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(2), stats.StreamsOpened)
+	assert.Equal(t, int64(1), stats.StreamFailures)
+	assert.Equal(t, int64(1), stats.ResumedStreams)
+	assert.Equal(t, int64(4), stats.FramesReceived)
+	assert.Equal(t, int64(2*len("data: one\n\n: keepalive\n\ndata: two\n\n")), stats.BytesReceived)
+	assert.Positive(t, stats.BytesSent)
+	assert.Positive(t, stats.LastHeaderLatency)
+	assert.Positive(t, stats.SmoothedHeaderLatency)
+
+	t.Run("reset returns and clears counters", func(t *testing.T) {
+		previous := client.ResetStats()
+		assert.Equal(t, stats, previous)
+		assert.Equal(t, ConnectionStats{}, client.Stats())
+	})
+
+	t.Run("concurrent reads are safe", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				frames, _, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+				if !assert.NoError(t, err) {
+					return
+				}
+				for range frames {
+					_ = client.Stats()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Eventually(t, func() bool {
+			return client.Stats().ActiveStreams == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, int64(8), client.Stats().FramesReceived)
+	})
+}
+
+func TestObserveHeaderLatency(t *testing.T) {
+	var stats clientStats
+
+	stats.observeHeaderLatency(100 * time.Millisecond)
+	assert.Equal(t, int64(100*time.Millisecond), stats.smoothedHeaderLatency.Load())
+
+	stats.observeHeaderLatency(200 * time.Millisecond)
+	assert.Equal(t, int64(200*time.Millisecond), stats.lastHeaderLatency.Load())
+	assert.Equal(t, int64(112500*time.Microsecond), stats.smoothedHeaderLatency.Load())
+}
+
 // Benchmark tests
 func BenchmarkStream(b *testing.B) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -715,10 +997,10 @@ func BenchmarkStream(b *testing.B) {
 		for range frames {
 			count++
 			if count >= 1000 {
-				cancel()
 				break
 			}
 		}
+		cancel()
 	}
 }
 
@@ -744,9 +1026,79 @@ func BenchmarkReadStream(b *testing.B) {
 		for range frames {
 			count++
 			if count >= 1000 {
-				cancel()
 				break
 			}
 		}
+		cancel()
 	}
 }
+
+func TestTypedErrors(t *testing.T) {
+	t.Run("encode error", func(t *testing.T) {
+		input := newTestRunAgentInput()
+		input.State = make(chan int)
+
+		_, _, err := NewClient(Config{Endpoint: "http://localhost"}).Stream(StreamOptions{Payload: input})
+		var encodeErr *EncodeError
+		require.True(t, errors.As(err, &encodeErr))
+		assert.Contains(t, err.Error(), "failed to marshal payload")
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		_, _, err := NewClient(Config{Endpoint: server.URL}).Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		assert.ErrorIs(t, err, ErrNotConnected)
+	})
+
+	t.Run("read timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client := NewClient(Config{Endpoint: server.URL, ReadTimeout: 100 * time.Millisecond})
+		frames, errs, err := client.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		select {
+		case err := <-errs:
+			assert.ErrorIs(t, err, ErrTimeout)
+		case <-time.After(2 * time.Second):
+			require.FailNow(t, "timeout waiting for read timeout error")
+		}
+		for range frames {
+		}
+	})
+
+	t.Run("run error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, "data: {\"type\":\"RUN_STARTED\",\"threadId\":\"thread-1\",\"runId\":\"run-1\"}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"RUN_ERROR\",\"code\":\"rate_limited\",\"message\":\"slow down\",\"runId\":\"run-1\"}\n\n")
+			fmt.Fprint(w, "data: ignored\n\n")
+		}))
+		defer server.Close()
+
+		frames, errs, err := NewClient(Config{Endpoint: server.URL}).Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+
+		var received []string
+		for frame := range frames {
+			received = append(received, string(frame.Data))
+		}
+		require.Len(t, received, 2)
+		assert.Contains(t, received[1], "RUN_ERROR")
+
+		err = <-errs
+		var runErr *RunError
+		require.True(t, errors.As(err, &runErr))
+		assert.Equal(t, &RunError{Code: "rate_limited", Message: "slow down", RunID: "run-1"}, runErr)
+		assert.EqualError(t, err, "run error rate_limited: slow down")
+	})
+}
@@ -0,0 +1,69 @@
+package sse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotConnected is wrapped by stream errors when the request could not
+	// reach the server
+	ErrNotConnected = errors.New("not connected")
+
+	// ErrTimeout is wrapped by stream errors when the server does not send
+	// response headers within Config.ConnectTimeout, or no data within
+	// Config.ReadTimeout
+	ErrTimeout = errors.New("timeout")
+)
+
+// RunError is reported on a stream's error channel when the server ends the
+// run with a RUN_ERROR event. The event's frame is delivered first.
+type RunError struct {
+	Code    string
+	Message string
+	RunID   string
+}
+
+func (e *RunError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("run error %s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("run error: %s", e.Message)
+}
+
+// EncodeError is returned by Stream when the run input cannot be encoded
+// into a request body
+type EncodeError struct {
+	Err error
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("failed to marshal payload: %v", e.Err)
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// runErrorFromFrame returns the RunError carried by a RUN_ERROR frame, or nil
+// for any other frame
+func runErrorFromFrame(data []byte) *RunError {
+	// Avoid parsing the common case of frames that are not RUN_ERROR events
+	if !strings.Contains(string(data), `"RUN_ERROR"`) {
+		return nil
+	}
+
+	var event struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		RunID   string `json:"runId"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil || event.Type != "RUN_ERROR" {
+		return nil
+	}
+
+	return &RunError{Code: event.Code, Message: event.Message, RunID: event.RunID}
+}
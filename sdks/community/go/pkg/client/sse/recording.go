@@ -0,0 +1,294 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Transport produces a stream of SSE frames for a run. *Client, Recorder and
+// Replayer all implement it, so code written against Transport can be pointed
+// at a live backend, a recording session, or a recorded file.
+type Transport interface {
+	Stream(opts StreamOptions) (<-chan Frame, <-chan error, error)
+}
+
+var (
+	_ Transport = (*Client)(nil)
+	_ Transport = (*Recorder)(nil)
+	_ Transport = (*Replayer)(nil)
+)
+
+// recordedFrame is one line of a recording: the frame data and its offset
+// from the start of the stream, or the error that ended the stream
+type recordedFrame struct {
+	OffsetMs int64  `json:"offsetMs"`
+	Data     string `json:"data"`
+	// Error is the message of a stream error, and ErrorKind names the
+	// sentinel it wrapped ("timeout" or "notConnected"), if any
+	Error     string `json:"error,omitempty"`
+	ErrorKind string `json:"errorKind,omitempty"`
+}
+
+// errorKinds maps the ErrorKind of a recorded error to its sentinel
+var errorKinds = map[string]error{
+	"timeout":      ErrTimeout,
+	"notConnected": ErrNotConnected,
+}
+
+// replayedError is a recorded stream error as reported by a Replayer. It
+// unwraps to the sentinel the original error wrapped, so errors.Is checks
+// behave as they did on the live stream.
+type replayedError struct {
+	message string
+	kind    error
+}
+
+func (e *replayedError) Error() string {
+	return e.message
+}
+
+func (e *replayedError) Unwrap() error {
+	return e.kind
+}
+
+// Recorder wraps a Transport and writes every frame it receives to w as one
+// JSON object per line, in a format Replayer can read back. Stream errors are
+// recorded too, except RunErrors, which a Replayer derives from the RUN_ERROR
+// frame as the live client does. Frames and errors are passed through
+// unchanged; a failure to write the recording does not affect the stream and
+// is reported by Err.
+type Recorder struct {
+	inner Transport
+
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewRecorder returns a Recorder that tees frames from inner to w
+func NewRecorder(inner Transport, w io.Writer) *Recorder {
+	return &Recorder{inner: inner, w: w}
+}
+
+// Stream starts a stream on the inner transport and records its frames
+func (r *Recorder) Stream(opts StreamOptions) (<-chan Frame, <-chan error, error) {
+	innerFrames, innerErrors, err := r.inner.Stream(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	frames := make(chan Frame, cap(innerFrames))
+	errs := make(chan error, 1)
+	start := time.Now()
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		for innerFrames != nil || innerErrors != nil {
+			select {
+			case frame, ok := <-innerFrames:
+				if !ok {
+					innerFrames = nil
+					continue
+				}
+				r.record(recordedFrame{
+					OffsetMs: offsetMs(frame.Timestamp, start),
+					Data:     string(frame.Data),
+				})
+				select {
+				case frames <- frame:
+				case <-ctx.Done():
+					return
+				}
+			case err, ok := <-innerErrors:
+				if !ok {
+					innerErrors = nil
+					continue
+				}
+				var runErr *RunError
+				if !errors.As(err, &runErr) {
+					r.record(recordedFrame{
+						OffsetMs:  offsetMs(time.Time{}, start),
+						Error:     err.Error(),
+						ErrorKind: errorKindOf(err),
+					})
+				}
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return frames, errs, nil
+}
+
+// Err returns the first error encountered while writing the recording
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(frame recordedFrame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	line, err := json.Marshal(frame)
+	if err != nil {
+		r.err = fmt.Errorf("failed to encode recorded frame: %w", err)
+		return
+	}
+
+	line = append(line, '\n')
+	if _, err := r.w.Write(line); err != nil {
+		r.err = fmt.Errorf("failed to write recorded frame: %w", err)
+	}
+}
+
+// offsetMs returns the offset of received from start, using the current time
+// when received is zero
+func offsetMs(received, start time.Time) int64 {
+	if received.IsZero() {
+		received = time.Now()
+	}
+	return received.Sub(start).Milliseconds()
+}
+
+// errorKindOf returns the ErrorKind recorded for err
+func errorKindOf(err error) string {
+	for kind, sentinel := range errorKinds {
+		if errors.Is(err, sentinel) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// Replayer is a Transport that serves frames from a recording made by
+// Recorder instead of contacting a server. Every call to Stream replays the
+// whole recording, which makes it suitable for deterministic tests.
+type Replayer struct {
+	frames         []recordedFrame
+	preserveTiming bool
+	bufferSize     int
+}
+
+// ReplayerOption configures a Replayer
+type ReplayerOption func(*Replayer)
+
+// WithPreserveTiming delays each frame by its recorded offset from the start
+// of the stream instead of delivering frames as fast as they are read
+func WithPreserveTiming() ReplayerOption {
+	return func(r *Replayer) {
+		r.preserveTiming = true
+	}
+}
+
+// NewReplayer reads a recording from r. The recording is loaded eagerly, so r
+// may be closed once NewReplayer returns.
+func NewReplayer(r io.Reader, options ...ReplayerOption) (*Replayer, error) {
+	replayer := &Replayer{bufferSize: 100}
+	for _, opt := range options {
+		opt(replayer)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var frame recordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("invalid recording at line %d: %w", line, err)
+		}
+		replayer.frames = append(replayer.frames, frame)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	return replayer, nil
+}
+
+// Stream replays the recorded frames and errors. Like the live client, it
+// ends the stream with a *RunError after delivering a RUN_ERROR frame. The
+// payload and headers in opts are ignored; cancelling opts.Context stops the
+// replay.
+func (r *Replayer) Stream(opts StreamOptions) (<-chan Frame, <-chan error, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	frames := make(chan Frame, r.bufferSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		start := time.Now()
+		for _, recorded := range r.frames {
+			if r.preserveTiming {
+				delay := time.Until(start.Add(time.Duration(recorded.OffsetMs) * time.Millisecond))
+				if delay > 0 {
+					timer := time.NewTimer(delay)
+					select {
+					case <-timer.C:
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					}
+				}
+			}
+
+			if recorded.Error != "" {
+				select {
+				case errs <- &replayedError{message: recorded.Error, kind: errorKinds[recorded.ErrorKind]}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			frame := Frame{Data: []byte(recorded.Data), Timestamp: time.Now()}
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return
+			}
+
+			if runErr := runErrorFromFrame(frame.Data); runErr != nil {
+				select {
+				case errs <- runErr:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+
+	return frames, errs, nil
+}
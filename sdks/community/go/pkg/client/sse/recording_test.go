@@ -0,0 +1,217 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectFrames(t *testing.T, frames <-chan Frame, errs <-chan error) []string {
+	t.Helper()
+
+	var data []string
+	timeout := time.After(5 * time.Second)
+	for frames != nil || errs != nil {
+		select {
+		case frame, ok := <-frames:
+			if !ok {
+				frames = nil
+				continue
+			}
+			data = append(data, string(frame.Data))
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			require.NoError(t, err)
+		case <-timeout:
+			require.FailNow(t, "timeout waiting for stream to end")
+		}
+	}
+	return data
+}
+
+func TestRecorderAndReplayer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+
+		fmt.Fprintf(w, "data: {\"type\":\"RUN_STARTED\",\"threadId\":\"thread-1\",\"runId\":\"run-1\"}\n\n")
+		flusher.Flush()
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintf(w, "data: line one\ndata: line two\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	var recording bytes.Buffer
+	recorder := NewRecorder(NewClient(Config{Endpoint: server.URL}), &recording)
+
+	frames, errs, err := recorder.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+	require.NoError(t, err)
+	live := collectFrames(t, frames, errs)
+	require.NoError(t, recorder.Err())
+
+	require.Equal(t, []string{
+		`{"type":"RUN_STARTED","threadId":"thread-1","runId":"run-1"}`,
+		"line one\nline two",
+	}, live)
+	assert.Equal(t, 2, strings.Count(recording.String(), "\n"))
+
+	t.Run("replays recorded frames", func(t *testing.T) {
+		replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+
+		var transport Transport = replayer
+		for i := 0; i < 2; i++ {
+			frames, errs, err := transport.Stream(StreamOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, live, collectFrames(t, frames, errs))
+		}
+	})
+
+	t.Run("preserves timing", func(t *testing.T) {
+		replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()), WithPreserveTiming())
+		require.NoError(t, err)
+
+		start := time.Now()
+		frames, errs, err := replayer.Stream(StreamOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, live, collectFrames(t, frames, errs))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("stops on context cancellation", func(t *testing.T) {
+		replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()), WithPreserveTiming())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		frames, errs, err := replayer.Stream(StreamOptions{Context: ctx})
+		require.NoError(t, err)
+
+		<-frames
+		cancel()
+		assert.Empty(t, collectFrames(t, frames, errs))
+	})
+}
+
+func TestRecorderAndReplayerErrors(t *testing.T) {
+	drain := func(t *testing.T, frames <-chan Frame, errs <-chan error) ([]string, []error) {
+		t.Helper()
+
+		var data []string
+		var streamErrs []error
+		timeout := time.After(5 * time.Second)
+		for frames != nil || errs != nil {
+			select {
+			case frame, ok := <-frames:
+				if !ok {
+					frames = nil
+					continue
+				}
+				data = append(data, string(frame.Data))
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				streamErrs = append(streamErrs, err)
+			case <-timeout:
+				require.FailNow(t, "timeout waiting for stream to end")
+			}
+		}
+		return data, streamErrs
+	}
+
+	t.Run("RUN_ERROR replays as a RunError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "data: {\"type\":\"RUN_ERROR\",\"code\":\"E_TOOL\",\"message\":\"tool failed\",\"runId\":\"run-1\"}\n\n")
+		}))
+		defer server.Close()
+
+		var recording bytes.Buffer
+		recorder := NewRecorder(NewClient(Config{Endpoint: server.URL}), &recording)
+		frames, errs, err := recorder.Stream(StreamOptions{Payload: newTestRunAgentInput()})
+		require.NoError(t, err)
+		liveFrames, liveErrs := drain(t, frames, errs)
+		require.NoError(t, recorder.Err())
+		require.Len(t, liveErrs, 1)
+
+		replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+		frames, errs, err = replayer.Stream(StreamOptions{})
+		require.NoError(t, err)
+		replayedFrames, replayedErrs := drain(t, frames, errs)
+
+		assert.Equal(t, liveFrames, replayedFrames)
+		require.Len(t, replayedErrs, 1)
+		var runErr *RunError
+		require.True(t, errors.As(replayedErrs[0], &runErr))
+		assert.Equal(t, liveErrs[0], replayedErrs[0])
+	})
+
+	t.Run("stream errors are recorded and replayed", func(t *testing.T) {
+		inner, err := NewReplayer(strings.NewReader(`{"offsetMs":0,"data":"one"}` + "\n" + `{"offsetMs":5,"data":"","error":"timeout: no data received for 1s","errorKind":"timeout"}` + "\n"))
+		require.NoError(t, err)
+
+		var recording bytes.Buffer
+		frames, errs, err := NewRecorder(inner, &recording).Stream(StreamOptions{})
+		require.NoError(t, err)
+		data, streamErrs := drain(t, frames, errs)
+		assert.Equal(t, []string{"one"}, data)
+		require.Len(t, streamErrs, 1)
+		assert.ErrorIs(t, streamErrs[0], ErrTimeout)
+
+		replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+		frames, errs, err = replayer.Stream(StreamOptions{})
+		require.NoError(t, err)
+		data, streamErrs = drain(t, frames, errs)
+		assert.Equal(t, []string{"one"}, data)
+		require.Len(t, streamErrs, 1)
+		assert.ErrorIs(t, streamErrs[0], ErrTimeout)
+		assert.EqualError(t, streamErrs[0], "timeout: no data received for 1s")
+	})
+}
+
+func TestRecorderWriteFailure(t *testing.T) {
+	replayer, err := NewReplayer(strings.NewReader(`{"offsetMs":0,"data":"one"}` + "\n" + `{"offsetMs":0,"data":"two"}` + "\n"))
+	require.NoError(t, err)
+
+	writeErr := errors.New("disk full")
+	recorder := NewRecorder(replayer, &failingWriter{err: writeErr})
+
+	frames, errs, err := recorder.Stream(StreamOptions{})
+	require.NoError(t, err)
+
+	// Recording failures never interrupt the stream itself
+	assert.Equal(t, []string{"one", "two"}, collectFrames(t, frames, errs))
+	assert.ErrorIs(t, recorder.Err(), writeErr)
+}
+
+func TestNewReplayerInvalidRecording(t *testing.T) {
+	_, err := NewReplayer(strings.NewReader(`{"offsetMs":0,"data":"ok"}` + "\nnot json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
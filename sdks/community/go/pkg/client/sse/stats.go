@@ -0,0 +1,103 @@
+package sse
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// headerLatencySmoothing is the weight given to each new sample in the
+// rolling header latency estimate, as in TCP's smoothed RTT
+const headerLatencySmoothing = 0.125
+
+// ConnectionStats is a point-in-time snapshot of a client's transport
+// counters. Counters accumulate from client creation or the last ResetStats.
+type ConnectionStats struct {
+	// StreamsOpened counts streams that were successfully established.
+	StreamsOpened int64
+	// StreamFailures counts streams that could not be established (network
+	// errors, non-200 responses or a non-SSE content type).
+	StreamFailures int64
+	// ResumedStreams counts streams opened with a Last-Event-ID header. The
+	// client does not reconnect on its own, so this counts requests that
+	// asked to resume, not dropped connections that were re-established.
+	ResumedStreams int64
+	// ActiveStreams is the number of streams currently being read. It is a
+	// gauge and is not affected by ResetStats.
+	ActiveStreams int64
+
+	// BytesSent counts request body bytes sent when opening streams.
+	BytesSent int64
+	// BytesReceived counts raw stream bytes read, including framing.
+	BytesReceived int64
+	// FramesReceived counts complete SSE data frames delivered.
+	FramesReceived int64
+
+	// LastHeaderLatency is the time from sending the most recent stream
+	// request to receiving its response headers. It includes the server's
+	// time to start the response, so it is not a network round trip.
+	LastHeaderLatency time.Duration
+	// SmoothedHeaderLatency is a rolling estimate of that latency across
+	// streams.
+	SmoothedHeaderLatency time.Duration
+}
+
+// clientStats holds the live counters behind ConnectionStats
+type clientStats struct {
+	streamsOpened         atomic.Int64
+	streamFailures        atomic.Int64
+	resumedStreams        atomic.Int64
+	activeStreams         atomic.Int64
+	bytesSent             atomic.Int64
+	bytesReceived         atomic.Int64
+	framesReceived        atomic.Int64
+	lastHeaderLatency     atomic.Int64
+	smoothedHeaderLatency atomic.Int64
+}
+
+// Stats returns a snapshot of the client's connection counters. It is safe
+// to call concurrently with active streams.
+func (c *Client) Stats() ConnectionStats {
+	return ConnectionStats{
+		StreamsOpened:         c.stats.streamsOpened.Load(),
+		StreamFailures:        c.stats.streamFailures.Load(),
+		ResumedStreams:        c.stats.resumedStreams.Load(),
+		ActiveStreams:         c.stats.activeStreams.Load(),
+		BytesSent:             c.stats.bytesSent.Load(),
+		BytesReceived:         c.stats.bytesReceived.Load(),
+		FramesReceived:        c.stats.framesReceived.Load(),
+		LastHeaderLatency:     time.Duration(c.stats.lastHeaderLatency.Load()),
+		SmoothedHeaderLatency: time.Duration(c.stats.smoothedHeaderLatency.Load()),
+	}
+}
+
+// ResetStats zeroes the accumulated counters and header latency estimates and returns
+// the values they held. ActiveStreams is left untouched.
+func (c *Client) ResetStats() ConnectionStats {
+	return ConnectionStats{
+		StreamsOpened:         c.stats.streamsOpened.Swap(0),
+		StreamFailures:        c.stats.streamFailures.Swap(0),
+		ResumedStreams:        c.stats.resumedStreams.Swap(0),
+		ActiveStreams:         c.stats.activeStreams.Load(),
+		BytesSent:             c.stats.bytesSent.Swap(0),
+		BytesReceived:         c.stats.bytesReceived.Swap(0),
+		FramesReceived:        c.stats.framesReceived.Swap(0),
+		LastHeaderLatency:     time.Duration(c.stats.lastHeaderLatency.Swap(0)),
+		SmoothedHeaderLatency: time.Duration(c.stats.smoothedHeaderLatency.Swap(0)),
+	}
+}
+
+// observeHeaderLatency records a header latency sample and folds it into the
+// rolling estimate
+func (s *clientStats) observeHeaderLatency(latency time.Duration) {
+	s.lastHeaderLatency.Store(int64(latency))
+	for {
+		old := s.smoothedHeaderLatency.Load()
+		next := int64(latency)
+		if old != 0 {
+			next = old + int64(headerLatencySmoothing*float64(int64(latency)-old))
+		}
+		if s.smoothedHeaderLatency.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
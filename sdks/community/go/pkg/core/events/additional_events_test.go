@@ -519,6 +519,27 @@ func TestRunErrorEvent_ToJSON(t *testing.T) {
 	assert.Equal(t, message, decoded["message"])
 	assert.Equal(t, code, decoded["code"])
 	assert.Equal(t, runID, decoded["runId"])
+	assert.NotContains(t, decoded, "causes")
+
+	t.Run("with causes", func(t *testing.T) {
+		event := NewRunErrorEvent("run failed",
+			WithErrorCode("RUN_FAILED"),
+			WithErrorCause("STEP_FAILED", "step plan failed"),
+			WithErrorCause("", "tool search timed out"),
+		)
+		require.NoError(t, event.Validate())
+
+		jsonData, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(jsonData), `"causes":[{"code":"STEP_FAILED","message":"step plan failed"},{"message":"tool search timed out"}]`)
+
+		decoded, err := EventFromJSON(jsonData)
+		require.NoError(t, err)
+		assert.Equal(t, event.Causes, decoded.(*RunErrorEvent).Causes)
+
+		event.Causes[1].Message = ""
+		assert.Error(t, event.Validate())
+	})
 }
 
 func TestStepEvents_ToJSON(t *testing.T) {
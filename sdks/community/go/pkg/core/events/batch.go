@@ -0,0 +1,139 @@
+package events
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions configures ValidateBatch
+type BatchOptions struct {
+	// Config is applied to every sequence
+	Config ValidationConfig
+	// Workers is the number of sequences validated in parallel. Defaults to
+	// GOMAXPROCS.
+	Workers int
+}
+
+// SequenceResult is the outcome of validating one sequence of a batch
+type SequenceResult struct {
+	// Index is the position of the sequence in the batch
+	Index int
+	// Validated is false if the batch was cancelled before the sequence was
+	// completely validated; Err is then nil.
+	Validated bool
+	// Event is the index of the first invalid event, or -1
+	Event    int
+	Err      error
+	Warnings []Warning
+}
+
+// BatchReport aggregates the results of ValidateBatch
+type BatchReport struct {
+	// Results holds one entry per input sequence, in input order
+	Results []SequenceResult
+	Valid   int
+	Invalid int
+	// Skipped counts the sequences that were not validated because the
+	// context was done
+	Skipped int
+	// Err is the context's error if validation stopped early
+	Err error
+	// Report lists the errors of all invalid sequences and the warnings of
+	// every validated sequence
+	Report *ErrorReport
+}
+
+// ValidateBatch validates many independent sequences in parallel, each with
+// its own Validator. Results are partial if ctx is done before the batch
+// completes: sequences that were not fully validated are reported as
+// skipped and Err is set.
+func ValidateBatch(ctx context.Context, sequences [][]Event, opts BatchOptions) *BatchReport {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(sequences))
+
+	results := make([]SequenceResult, len(sequences))
+	for i := range results {
+		results[i] = SequenceResult{Index: i, Event: -1}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = validateBatchSequence(ctx, i, sequences[i], opts.Config)
+			}
+		}()
+	}
+
+feed:
+	for i := range sequences {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	report := &BatchReport{Results: results, Report: &ErrorReport{}}
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			report.Report.Warnings = append(report.Report.Warnings, ReportIssue{
+				Sequence: result.Index,
+				Event:    warning.Event,
+				Message:  warning.Message,
+			})
+		}
+
+		switch {
+		case !result.Validated:
+			report.Skipped++
+		case result.Err != nil:
+			report.Invalid++
+			report.Report.Errors = append(report.Report.Errors, ReportIssue{
+				Sequence: result.Index,
+				Event:    result.Event,
+				Message:  result.Err.Error(),
+			})
+		default:
+			report.Valid++
+		}
+	}
+	if report.Skipped > 0 {
+		report.Err = ctx.Err()
+	}
+
+	return report
+}
+
+// validateBatchSequence validates a single sequence, stopping early if ctx
+// is done
+func validateBatchSequence(ctx context.Context, index int, seq []Event, config ValidationConfig) SequenceResult {
+	result := SequenceResult{Index: index, Event: -1}
+
+	validator := NewValidator(config)
+	for i, event := range seq {
+		if ctx.Err() != nil {
+			return result
+		}
+		if err := validator.ValidateEvent(event); err != nil {
+			result.Validated = true
+			result.Event = i
+			result.Err = err
+			result.Warnings = validator.Warnings()
+			return result
+		}
+	}
+
+	result.Validated = true
+	result.Warnings = validator.Warnings()
+	return result
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBatch(t *testing.T) {
+	valid := func(i int) []Event {
+		runID := fmt.Sprintf("run-%d", i)
+		return []Event{NewRunStartedEvent("thread-1", runID), NewRunFinishedEvent("thread-1", runID)}
+	}
+
+	t.Run("aggregates results in input order", func(t *testing.T) {
+		sequences := make([][]Event, 50)
+		for i := range sequences {
+			sequences[i] = valid(i)
+		}
+		sequences[7] = []Event{NewRunStartedEvent("thread-1", "run-7"), NewTextMessageEndEvent("msg-1")}
+		sequences[31] = toolCallSequence("tool-1", `{"q":`)
+
+		report := ValidateBatch(context.Background(), sequences, BatchOptions{
+			Config:  ValidationConfig{ValidateToolCallArgsJSON: true},
+			Workers: 4,
+		})
+
+		require.NoError(t, report.Err)
+		assert.Equal(t, 48, report.Valid)
+		assert.Equal(t, 2, report.Invalid)
+		assert.Zero(t, report.Skipped)
+		require.Len(t, report.Results, 50)
+		for i, result := range report.Results {
+			assert.Equal(t, i, result.Index)
+			assert.True(t, result.Validated)
+		}
+
+		assert.Equal(t, 1, report.Results[7].Event)
+		assert.EqualError(t, report.Results[7].Err, "cannot end message msg-1 that was not started")
+		var argsErr *ToolCallArgsError
+		assert.ErrorAs(t, report.Results[31].Err, &argsErr)
+
+		require.True(t, report.Report.HasErrors())
+		require.Len(t, report.Report.Errors, 2)
+		assert.Equal(t, ReportIssue{Sequence: 7, Event: 1, Message: "cannot end message msg-1 that was not started"}, report.Report.Errors[0])
+		assert.Equal(t, 31, report.Report.Errors[1].Sequence)
+		assert.Contains(t, report.Report.String(), "error: sequence 7, event 1: cannot end message msg-1")
+
+		data, err := json.Marshal(report.Report)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `{"sequence":7,"event":1,"message":"cannot end message msg-1 that was not started"}`)
+	})
+
+	t.Run("cancelled context returns partial results", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		report := ValidateBatch(ctx, [][]Event{valid(0), valid(1)}, BatchOptions{})
+		assert.ErrorIs(t, report.Err, context.Canceled)
+		assert.Equal(t, 2, report.Skipped)
+		assert.False(t, report.Results[0].Validated)
+		assert.False(t, report.Report.HasErrors())
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		report := ValidateBatch(context.Background(), nil, BatchOptions{})
+		assert.NoError(t, report.Err)
+		assert.Empty(t, report.Results)
+		assert.Empty(t, report.Report.String())
+	})
+}
+
+func TestValidationReportEvent(t *testing.T) {
+	report := &ErrorReport{
+		Errors:   []ReportIssue{{Sequence: 0, Event: 2, Message: "cannot end message msg-1 that was not started"}},
+		Warnings: []ReportIssue{{Sequence: 0, Event: 0, Message: "RAW.source is deprecated"}},
+	}
+
+	event := NewValidationReportEvent(report)
+	require.NoError(t, event.Validate())
+	assert.Equal(t, ValidationReportEventName, event.Name)
+
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+
+	decoded, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+	require.NoError(t, err)
+	assert.Equal(t, *report, decoded.(*CustomEvent).Value)
+
+	got, ok := ValidationReportFromEvent(decoded)
+	require.True(t, ok)
+	assert.Equal(t, report, got)
+
+	_, ok = ValidationReportFromEvent(NewCustomEvent("other"))
+	assert.False(t, ok)
+
+	empty, ok := ValidationReportFromEvent(NewValidationReportEvent(nil))
+	require.True(t, ok)
+	assert.False(t, empty.HasErrors())
+}
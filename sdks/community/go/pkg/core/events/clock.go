@@ -0,0 +1,71 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of the current time for event timestamps, timestamp
+// IDs, validators and logs
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock reads the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Global clock instance used by the package
+var clock Clock = realClock{}
+
+// SetClock replaces the package clock. It exists for tests that need
+// deterministic timestamps, for example with a ManualClock, and should be
+// set once before events are created rather than changed concurrently with
+// their use. A nil clock restores the system clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+	clock = c
+}
+
+// clockNow returns the current time of the package clock
+func clockNow() time.Time {
+	return clock.Now()
+}
+
+// ManualClock is a Clock that only moves when told to. It is safe for
+// concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock creates a manual clock set to t
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now returns the clock's current time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetClock(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	clock := NewManualClock(start)
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	event := NewRunStartedEvent("thread-1", "run-1")
+	require.NotNil(t, event.Timestamp())
+	assert.Equal(t, start.UnixMilli(), *event.Timestamp())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second).UnixMilli(), *AcquireTextMessageContentEvent("msg-1", "hi").Timestamp())
+	assert.Contains(t, NewTimestampIDGenerator("").GenerateRunID(), "run-1700000001000-")
+
+	t.Run("timestamp validation", func(t *testing.T) {
+		v := NewValidator(ProductionValidationConfig())
+		future := NewRunStartedEvent("thread-1", "run-1")
+		future.SetTimestamp(clock.Now().Add(time.Minute).UnixMilli())
+		assert.Error(t, v.ValidateEvent(future))
+
+		// The validator reads the package clock on every event
+		clock.Advance(2 * time.Minute)
+		v.Reset()
+		assert.NoError(t, v.ValidateEvent(future))
+	})
+
+	t.Run("log times", func(t *testing.T) {
+		clock.Set(start)
+		event := NewStepStartedEvent("plan")
+		event.TimestampMs = nil
+
+		entry := NewLog().Append(event)
+		assert.Equal(t, start, entry.Time)
+	})
+
+	t.Run("nil restores the system clock", func(t *testing.T) {
+		SetClock(nil)
+		defer SetClock(clock)
+
+		assert.WithinDuration(t, time.Now(), time.UnixMilli(*NewStepStartedEvent("plan").Timestamp()), time.Minute)
+	})
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+)
+
+// MessagesToEvents flattens a conversation into an event sequence that
+// replays it, for export or replay: RUN_STARTED, then for each message its
+// text message events, the tool calls of assistant messages and the results
+// of tool messages, then a MESSAGES_SNAPSHOT of the whole conversation and
+// RUN_FINISHED. Reasoning, activity and non-text user messages, and tool
+// messages without content or error, only appear in the snapshot.
+//
+// The thread ID comes before the run ID, as in NewRunStartedEvent and
+// NewRunFinishedEvent. Messages without an ID, and empty thread and run IDs,
+// are given one by the default ID generator; the input slice is not
+// modified. The messages must form a valid MESSAGES_SNAPSHOT.
+func MessagesToEvents(threadID, runID string, messages []Message) ([]Event, error) {
+	if threadID == "" {
+		threadID = GenerateThreadID()
+	}
+	if runID == "" {
+		runID = GenerateRunID()
+	}
+
+	messages = slices.Clone(messages)
+	for i := range messages {
+		if messages[i].ID == "" {
+			messages[i].ID = GenerateMessageID()
+		}
+	}
+
+	snapshot := NewMessagesSnapshotEvent(messages)
+	if err := snapshot.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot convert messages to events: %w", err)
+	}
+
+	seq := []Event{NewRunStartedEvent(threadID, runID)}
+	for _, msg := range messages {
+		switch msg.Role {
+		case coretypes.RoleUser, coretypes.RoleSystem, coretypes.RoleDeveloper, coretypes.RoleAssistant:
+			if content, ok := msg.ContentString(); ok && (content != "" || len(msg.ToolCalls) == 0) {
+				seq = append(seq, NewTextMessageStartEvent(msg.ID, WithRole(string(msg.Role))))
+				if content != "" {
+					seq = append(seq, NewTextMessageContentEvent(msg.ID, content))
+				}
+				seq = append(seq, NewTextMessageEndEvent(msg.ID))
+			}
+
+			for _, toolCall := range msg.ToolCalls {
+				seq = append(seq, NewToolCallStartEvent(toolCall.ID, toolCall.Function.Name, WithParentMessageID(msg.ID)))
+				if toolCall.Function.Arguments != "" {
+					seq = append(seq, NewToolCallArgsEvent(toolCall.ID, toolCall.Function.Arguments))
+				}
+				seq = append(seq, NewToolCallEndEvent(toolCall.ID))
+			}
+
+		case coretypes.RoleTool:
+			// Results need content; a failed call without any is
+			// represented by its error
+			content, _ := msg.ContentString()
+			if content == "" {
+				content = msg.Error
+			}
+			if content != "" {
+				seq = append(seq, NewToolCallResultEvent(msg.ID, msg.ToolCallID, content))
+			}
+		}
+	}
+
+	return append(seq, snapshot, NewRunFinishedEvent(threadID, runID)), nil
+}
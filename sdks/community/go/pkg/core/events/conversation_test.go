@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagesToEvents(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "Be brief."},
+		{ID: "user-1", Role: "user", Content: "Weather in SF?"},
+		{ID: "assistant-1", Role: "assistant", ToolCalls: []ToolCall{{
+			ID:       "tool-1",
+			Type:     "function",
+			Function: Function{Name: "get_weather", Arguments: `{"location":"SF"}`},
+		}}},
+		{ID: "result-1", Role: "tool", Content: "sunny", ToolCallID: "tool-1"},
+		{ID: "assistant-2", Role: "assistant", Content: "It is sunny."},
+	}
+
+	seq, err := MessagesToEvents("thread-1", "run-1", messages)
+	require.NoError(t, err)
+
+	config := DefaultValidationConfig()
+	config.RequireActiveRun = true
+	config.ValidateToolCallArgsJSON = true
+	require.NoError(t, ValidateSequenceWithConfig(seq, config))
+
+	assert.Equal(t, []EventType{
+		EventTypeRunStarted,
+		EventTypeTextMessageStart, EventTypeTextMessageContent, EventTypeTextMessageEnd,
+		EventTypeTextMessageStart, EventTypeTextMessageContent, EventTypeTextMessageEnd,
+		EventTypeToolCallStart, EventTypeToolCallArgs, EventTypeToolCallEnd,
+		EventTypeToolCallResult,
+		EventTypeTextMessageStart, EventTypeTextMessageContent, EventTypeTextMessageEnd,
+		EventTypeMessagesSnapshot,
+		EventTypeRunFinished,
+	}, eventTypes(seq))
+
+	assert.Equal(t, "system", *seq[1].(*TextMessageStartEvent).Role)
+	assert.Equal(t, "assistant-1", *seq[7].(*ToolCallStartEvent).ParentMessageID)
+
+	snapshot := seq[14].(*MessagesSnapshotEvent)
+	require.Len(t, snapshot.Messages, len(messages))
+	assert.NotEmpty(t, snapshot.Messages[0].ID)
+	assert.Equal(t, snapshot.Messages[0].ID, seq[1].(*TextMessageStartEvent).MessageID)
+	assert.Empty(t, messages[0].ID, "input messages must not be modified")
+
+	t.Run("generates missing run and thread IDs", func(t *testing.T) {
+		seq, err := MessagesToEvents("", "", messages[:2])
+		require.NoError(t, err)
+		started := seq[0].(*RunStartedEvent)
+		assert.NotEmpty(t, started.ThreadID())
+		assert.NotEmpty(t, started.RunID())
+	})
+
+	t.Run("rejects invalid conversations", func(t *testing.T) {
+		_, err := MessagesToEvents("thread-1", "run-1", []Message{messages[3]})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot convert messages to events")
+	})
+}
@@ -3,6 +3,7 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // RawEvent contains raw event data that should be passed through without processing
@@ -54,11 +55,17 @@ func (e *RawEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
-// CustomEvent contains custom application-specific event data
+// CustomEvent contains custom application-specific event data. A value
+// containing NaN or infinite floats fails to serialize with a
+// NonFiniteNumberError.
 type CustomEvent struct {
 	*BaseEvent
 	Name  string `json:"name"`
 	Value any    `json:"value,omitempty"`
+	// SchemaURI identifies the schema the value conforms to, making the
+	// event self-describing. It is serialized as "$schema" and omitted when
+	// empty.
+	SchemaURI string `json:"$schema,omitempty"`
 }
 
 // NewCustomEvent creates a new custom event
@@ -85,6 +92,14 @@ func WithValue(value any) CustomEventOption {
 	}
 }
 
+// WithSchemaURI sets the schema URI of the custom event and returns it.
+// The value itself is passed through untouched, so annotations such as
+// JSON-LD "@context" and "@type" keys survive encoding and decoding.
+func (e *CustomEvent) WithSchemaURI(uri string) *CustomEvent {
+	e.SchemaURI = uri
+	return e
+}
+
 // Validate validates the custom event
 func (e *CustomEvent) Validate() error {
 	if err := e.BaseEvent.Validate(); err != nil {
@@ -95,6 +110,12 @@ func (e *CustomEvent) Validate() error {
 		return fmt.Errorf("CustomEvent validation failed: name field is required")
 	}
 
+	if e.SchemaURI != "" {
+		if u, err := url.Parse(e.SchemaURI); err != nil || !u.IsAbs() {
+			return fmt.Errorf("CustomEvent validation failed: $schema must be an absolute URI, got: %s", e.SchemaURI)
+		}
+	}
+
 	return nil
 }
 
@@ -102,3 +123,14 @@ func (e *CustomEvent) Validate() error {
 func (e *CustomEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
+
+// MarshalJSON rejects values containing NaN or infinite floats, so that
+// every codec reports the same NonFiniteNumberError
+func (e *CustomEvent) MarshalJSON() ([]byte, error) {
+	if err := checkFinite(EventTypeCustom, "/value", e.Value); err != nil {
+		return nil, err
+	}
+
+	type customEvent CustomEvent
+	return json.Marshal((*customEvent)(e))
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// builtinCustomEvents maps the CUSTOM event names defined by this SDK to
+// their payload types. They are registered up front, but a peer may still
+// send one of those names with a payload of its own, so decoding leaves such
+// values generic instead of failing.
+var builtinCustomEvents = map[string]reflect.Type{
+	ToolCallProgressEventName: reflect.TypeFor[ToolCallProgress](),
+	StepErrorEventName:        reflect.TypeFor[StepError](),
+	ValidationReportEventName: reflect.TypeFor[ErrorReport](),
+}
+
+// customEventTypes maps registered CUSTOM event names to their payload types
+var customEventTypes = struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}{types: maps.Clone(builtinCustomEvents)}
+
+// RegisterCustomEvent associates the payload type T with a CUSTOM event
+// name. Decoders then unmarshal the value of CUSTOM events with that name
+// into a T, so handlers can type-assert CustomEvent.Value directly. It is
+// meant to be called during initialization and panics if name is empty or
+// already registered with a different type.
+func RegisterCustomEvent[T any](name string) {
+	if name == "" {
+		panic("events: RegisterCustomEvent called with an empty name")
+	}
+
+	t := reflect.TypeFor[T]()
+
+	customEventTypes.mu.Lock()
+	defer customEventTypes.mu.Unlock()
+
+	if existing, ok := customEventTypes.types[name]; ok && existing != t {
+		panic(fmt.Sprintf("events: custom event %q already registered with type %s", name, existing))
+	}
+	customEventTypes.types[name] = t
+}
+
+// NewTypedCustomEvent creates a custom event carrying a typed value
+func NewTypedCustomEvent[T any](name string, value T, options ...CustomEventOption) *CustomEvent {
+	return NewCustomEvent(name, append([]CustomEventOption{WithValue(value)}, options...)...)
+}
+
+// CustomEventValue returns the value of a custom event as a T. Values that
+// are already a T, such as those of registered events after decoding, are
+// returned as is; other values are converted through JSON.
+func CustomEventValue[T any](e *CustomEvent) (T, error) {
+	var result T
+	if e == nil {
+		return result, fmt.Errorf("custom event is nil")
+	}
+
+	if value, ok := e.Value.(T); ok {
+		return value, nil
+	}
+
+	data, err := json.Marshal(e.Value)
+	if err != nil {
+		return result, fmt.Errorf("failed to convert value of custom event %q: %w", e.Name, err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("value of custom event %q is not a %T: %w", e.Name, result, err)
+	}
+
+	return result, nil
+}
+
+// DecodeCustomEventValue replaces the generically decoded value of a custom
+// event with its registered payload type, if the event's name was
+// registered with RegisterCustomEvent. Event decoders call it after
+// decoding a CUSTOM event; unregistered names are left untouched, as are
+// values of the SDK's own event names that do not match their payload type.
+func DecodeCustomEventValue(e *CustomEvent) error {
+	customEventTypes.mu.RLock()
+	t, ok := customEventTypes.types[e.Name]
+	customEventTypes.mu.RUnlock()
+
+	if !ok || e.Value == nil || reflect.TypeOf(e.Value) == t {
+		return nil
+	}
+
+	data, err := json.Marshal(e.Value)
+	if err != nil {
+		return fmt.Errorf("failed to convert value of custom event %q: %w", e.Name, err)
+	}
+
+	typed := reflect.New(t)
+	if err := json.Unmarshal(data, typed.Interface()); err != nil {
+		if builtinCustomEvents[e.Name] == t {
+			return nil
+		}
+		return fmt.Errorf("value of custom event %q does not match registered type %s: %w", e.Name, t, err)
+	}
+	e.Value = typed.Elem().Interface()
+
+	return nil
+}
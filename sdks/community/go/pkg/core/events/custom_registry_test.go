@@ -0,0 +1,105 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userAction struct {
+	Action string `json:"action"`
+	Count  int    `json:"count"`
+}
+
+func TestRegisterCustomEvent(t *testing.T) {
+	RegisterCustomEvent[userAction]("test-user-action")
+
+	t.Run("typed constructor round-trips through the decoder", func(t *testing.T) {
+		event := NewTypedCustomEvent("test-user-action", userAction{Action: "click", Count: 2})
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+
+		decoded, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+		require.NoError(t, err)
+
+		custom := decoded.(*CustomEvent)
+		assert.Equal(t, userAction{Action: "click", Count: 2}, custom.Value)
+
+		value, err := CustomEventValue[userAction](custom)
+		require.NoError(t, err)
+		assert.Equal(t, "click", value.Action)
+	})
+
+	t.Run("mismatched payloads fail to decode", func(t *testing.T) {
+		data := []byte(`{"type":"CUSTOM","name":"test-user-action","value":{"count":"many"}}`)
+		_, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `value of custom event "test-user-action" does not match registered type events.userAction`)
+	})
+
+	t.Run("unregistered names are left generic", func(t *testing.T) {
+		data := []byte(`{"type":"CUSTOM","name":"test-unregistered","value":{"action":"click"}}`)
+		decoded, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+		require.NoError(t, err)
+
+		custom := decoded.(*CustomEvent)
+		assert.Equal(t, map[string]any{"action": "click"}, custom.Value)
+
+		value, err := CustomEventValue[userAction](custom)
+		require.NoError(t, err)
+		assert.Equal(t, userAction{Action: "click"}, value)
+	})
+
+	t.Run("foreign payloads under built-in names stay generic", func(t *testing.T) {
+		data := []byte(`{"type":"CUSTOM","name":"` + StepErrorEventName + `","value":{"stepName":42}}`)
+		decoded, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"stepName": float64(42)}, decoded.(*CustomEvent).Value)
+	})
+
+	t.Run("registration rules", func(t *testing.T) {
+		assert.NotPanics(t, func() { RegisterCustomEvent[userAction]("test-user-action") })
+		assert.Panics(t, func() { RegisterCustomEvent[string]("test-user-action") })
+		assert.Panics(t, func() { RegisterCustomEvent[string]("") })
+	})
+
+	t.Run("CustomEventValue reports conversion errors", func(t *testing.T) {
+		_, err := CustomEventValue[userAction](NewCustomEvent("test-other", WithValue("text")))
+		assert.Error(t, err)
+
+		_, err = CustomEventValue[userAction](nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCustomEventSchemaURI(t *testing.T) {
+	value := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "SearchAction",
+		"query":    "weather",
+	}
+	event := NewCustomEvent("analytics", WithValue(value)).WithSchemaURI("https://example.com/schemas/search.json")
+	require.NoError(t, event.Validate())
+
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"$schema":"https://example.com/schemas/search.json"`)
+
+	decoded, err := EventFromJSON(data)
+	require.NoError(t, err)
+	custom := decoded.(*CustomEvent)
+	assert.Equal(t, "https://example.com/schemas/search.json", custom.SchemaURI)
+	assert.Equal(t, value, custom.Value, "annotations are passed through")
+
+	plain, err := NewCustomEvent("analytics", WithValue(value)).ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "$schema")
+
+	assert.Error(t, NewCustomEvent("analytics").WithSchemaURI("schemas/search.json").Validate())
+
+	config := ValidationConfig{RequireCustomEventSchema: true}
+	assert.NoError(t, ValidateSequenceWithConfig([]Event{event}, config))
+	assert.EqualError(t, ValidateSequenceWithConfig([]Event{NewCustomEvent("analytics")}, config), "custom event analytics has no schema URI")
+	assert.NoError(t, ValidateSequence([]Event{NewCustomEvent("analytics")}))
+}
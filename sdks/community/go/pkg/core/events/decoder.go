@@ -247,6 +247,9 @@ func (ed *EventDecoder) DecodeEvent(eventName string, data []byte) (Event, error
 		if err := json.Unmarshal(data, &evt); err != nil {
 			return nil, fmt.Errorf("failed to decode CUSTOM: %w", err)
 		}
+		if err := DecodeCustomEventValue(&evt); err != nil {
+			return nil, fmt.Errorf("failed to decode CUSTOM: %w", err)
+		}
 		return &evt, nil
 
 	case EventTypeRaw:
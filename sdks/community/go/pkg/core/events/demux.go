@@ -0,0 +1,42 @@
+package events
+
+// Demux separates the interleaved streams of concurrently running tool calls.
+// It returns the events of seq that belong to each tool call, keyed by tool
+// call ID and in their original order: TOOL_CALL_START, TOOL_CALL_ARGS,
+// TOOL_CALL_END and TOOL_CALL_RESULT events, tool call progress events, and
+// TOOL_CALL_CHUNK events. A chunk without an ID continues the tool call of
+// the previous chunk. Events unrelated to tool calls are left out.
+func Demux(seq []Event) map[string][]Event {
+	streams := make(map[string][]Event)
+	var chunkToolCallID string
+
+	for _, event := range seq {
+		var toolCallID string
+
+		switch e := event.(type) {
+		case *ToolCallStartEvent:
+			toolCallID = e.ToolCallID
+		case *ToolCallArgsEvent:
+			toolCallID = e.ToolCallID
+		case *ToolCallEndEvent:
+			toolCallID = e.ToolCallID
+		case *ToolCallResultEvent:
+			toolCallID = e.ToolCallID
+		case *ToolCallChunkEvent:
+			if e.ToolCallID != nil && *e.ToolCallID != "" {
+				chunkToolCallID = *e.ToolCallID
+			}
+			toolCallID = chunkToolCallID
+		case *CustomEvent:
+			if progress, ok := ToolCallProgressFromEvent(e); ok {
+				toolCallID = progress.ToolCallID
+			}
+		}
+
+		if toolCallID != "" {
+			streams[toolCallID] = append(streams[toolCallID], event)
+		}
+	}
+
+	return streams
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemux(t *testing.T) {
+	seq := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewToolCallStartEvent("tool-a", "search"),
+		NewToolCallStartEvent("tool-b", "fetch"),
+		NewToolCallArgsEvent("tool-a", `{"query":`),
+		NewToolCallArgsEvent("tool-b", `{"url":"https://example.com"}`),
+		NewToolCallProgressEvent("tool-b", 1, 2, ""),
+		NewToolCallArgsEvent("tool-a", `"weather"}`),
+		NewToolCallEndEvent("tool-b"),
+		NewToolCallEndEvent("tool-a"),
+		NewToolCallResultEvent("msg-1", "tool-a", "sunny"),
+		NewRunFinishedEvent("thread-1", "run-1"),
+	}
+
+	t.Run("interleaved tool calls validate", func(t *testing.T) {
+		config := DefaultValidationConfig()
+		config.ValidateToolCallArgsJSON = true
+		require.NoError(t, ValidateSequenceWithConfig(seq, config))
+	})
+
+	streams := Demux(seq)
+	require.Len(t, streams, 2)
+
+	assert.Equal(t, []EventType{
+		EventTypeToolCallStart,
+		EventTypeToolCallArgs,
+		EventTypeToolCallArgs,
+		EventTypeToolCallEnd,
+		EventTypeToolCallResult,
+	}, eventTypes(streams["tool-a"]))
+	assert.Equal(t, `"weather"}`, streams["tool-a"][2].(*ToolCallArgsEvent).Delta)
+
+	assert.Equal(t, []EventType{
+		EventTypeToolCallStart,
+		EventTypeToolCallArgs,
+		EventTypeCustom,
+		EventTypeToolCallEnd,
+	}, eventTypes(streams["tool-b"]))
+
+	t.Run("chunks without an ID continue the previous chunk", func(t *testing.T) {
+		first := NewToolCallChunkEvent().WithToolCallChunkID("tool-c").WithToolCallChunkDelta(`{"a":`)
+		next := NewToolCallChunkEvent().WithToolCallChunkDelta(`1}`)
+
+		streams := Demux([]Event{first, NewStepStartedEvent("plan"), next})
+		assert.Equal(t, []Event{first, next}, streams["tool-c"])
+	})
+}
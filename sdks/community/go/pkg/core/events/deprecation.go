@@ -0,0 +1,106 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// FieldDeprecation describes a deprecated event field
+type FieldDeprecation struct {
+	EventType EventType
+	// Field is the JSON name of the deprecated field
+	Field string
+	// Since is the protocol or SDK version that deprecated the field
+	Since string
+	// Replacement is the JSON name of the field to use instead, if any
+	Replacement string
+}
+
+// Message returns the migration hint for a deprecated field
+func (d FieldDeprecation) Message() string {
+	msg := fmt.Sprintf("%s field %s is deprecated", d.EventType, d.Field)
+	if d.Since != "" {
+		msg += " since " + d.Since
+	}
+	if d.Replacement != "" {
+		return msg + "; use " + d.Replacement + " instead"
+	}
+	return msg
+}
+
+// deprecatedFields holds the registered field deprecations by event type
+var deprecatedFields = struct {
+	mu     sync.RWMutex
+	fields map[EventType][]FieldDeprecation
+}{fields: make(map[EventType][]FieldDeprecation)}
+
+// DeprecateField marks a field of an event type as deprecated. Validators
+// then record a Warning, rather than an error, for every event of that type
+// that sets the field. Registering the same field again replaces its
+// deprecation.
+func DeprecateField(eventType EventType, field, since, replacement string) {
+	deprecation := FieldDeprecation{EventType: eventType, Field: field, Since: since, Replacement: replacement}
+
+	deprecatedFields.mu.Lock()
+	defer deprecatedFields.mu.Unlock()
+
+	// Readers iterate the stored slice without holding the lock, so it is
+	// never modified in place
+	updated := slices.Clone(deprecatedFields.fields[eventType])
+	if i := slices.IndexFunc(updated, func(d FieldDeprecation) bool { return d.Field == field }); i >= 0 {
+		updated[i] = deprecation
+	} else {
+		updated = append(updated, deprecation)
+	}
+	deprecatedFields.fields[eventType] = updated
+}
+
+// Warning is a non-fatal validation finding, such as the use of a
+// deprecated field
+type Warning struct {
+	// Event is the index of the event in the validated stream
+	Event     int
+	EventType EventType
+	Field     string
+	Message   string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("event %d: %s", w.Event, w.Message)
+}
+
+// deprecationWarnings returns a warning for every deprecated field set on
+// event
+func deprecationWarnings(i int, event Event) []Warning {
+	deprecatedFields.mu.RLock()
+	deprecations := deprecatedFields.fields[event.Type()]
+	deprecatedFields.mu.RUnlock()
+
+	if len(deprecations) == 0 {
+		return nil
+	}
+
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	var warnings []Warning
+	for _, d := range deprecations {
+		if value, ok := fields[d.Field]; ok && string(value) != "null" {
+			warnings = append(warnings, Warning{
+				Event:     i,
+				EventType: d.EventType,
+				Field:     d.Field,
+				Message:   d.Message(),
+			})
+		}
+	}
+	return warnings
+}
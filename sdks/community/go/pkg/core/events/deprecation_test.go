@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deprecateFieldForTest registers a deprecation for the duration of a test
+func deprecateFieldForTest(t *testing.T, eventType EventType, field, since, replacement string) {
+	t.Helper()
+	DeprecateField(eventType, field, since, replacement)
+	t.Cleanup(func() {
+		deprecatedFields.mu.Lock()
+		defer deprecatedFields.mu.Unlock()
+		delete(deprecatedFields.fields, eventType)
+	})
+}
+
+func TestDeprecateField(t *testing.T) {
+	deprecateFieldForTest(t, EventTypeRaw, "source", "0.9.0", "origin")
+
+	t.Run("validator records warnings", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+		require.NoError(t, v.ValidateEvent(NewRawEvent("payload")))
+		require.NoError(t, v.ValidateEvent(NewRawEvent("payload", WithSource("upstream"))))
+
+		assert.Equal(t, []Warning{{
+			Event:     1,
+			EventType: EventTypeRaw,
+			Field:     "source",
+			Message:   "RAW field source is deprecated since 0.9.0; use origin instead",
+		}}, v.Warnings())
+		assert.Equal(t, "event 1: RAW field source is deprecated since 0.9.0; use origin instead", v.Warnings()[0].String())
+
+		v.Reset()
+		assert.Empty(t, v.Warnings())
+	})
+
+	t.Run("re-registering replaces the deprecation", func(t *testing.T) {
+		DeprecateField(EventTypeRaw, "source", "", "")
+
+		v := NewValidator(DefaultValidationConfig())
+		require.NoError(t, v.ValidateEvent(NewRawEvent("payload", WithSource("upstream"))))
+		require.Len(t, v.Warnings(), 1)
+		assert.Equal(t, "RAW field source is deprecated", v.Warnings()[0].Message)
+	})
+
+	t.Run("batch report warnings section", func(t *testing.T) {
+		DeprecateField(EventTypeRaw, "source", "0.9.0", "origin")
+
+		report := ValidateBatch(context.Background(), [][]Event{
+			{NewRawEvent("payload")},
+			{NewRawEvent("payload", WithSource("upstream"))},
+		}, BatchOptions{})
+
+		assert.Equal(t, 2, report.Valid)
+		assert.False(t, report.Report.HasErrors())
+		require.Len(t, report.Report.Warnings, 1)
+		assert.Equal(t, ReportIssue{Sequence: 1, Event: 0, Message: "RAW field source is deprecated since 0.9.0; use origin instead"}, report.Report.Warnings[0])
+		assert.Equal(t, "warning: sequence 1, event 0: RAW field source is deprecated since 0.9.0; use origin instead\n", report.Report.String())
+
+		data, err := json.Marshal(report.Report)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"warnings":[{"sequence":1,"event":0,"message":"RAW field source is deprecated since 0.9.0; use origin instead"}]}`, string(data))
+	})
+}
+
+func TestDeprecateFieldConcurrentValidation(t *testing.T) {
+	deprecateFieldForTest(t, EventTypeRaw, "source", "0.9.0", "origin")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			DeprecateField(EventTypeRaw, "source", "0.9.0", "origin")
+			DeprecateField(EventTypeRaw, "event", "0.9.0", "")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		v := NewValidator(DefaultValidationConfig())
+		for i := 0; i < 200; i++ {
+			assert.NoError(t, v.ValidateEvent(NewRawEvent("payload", WithSource("upstream"))))
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,201 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EqualOption configures Equal
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	compareTimestamps bool
+}
+
+// WithTimestamps makes Equal compare event timestamps, which it ignores by
+// default
+func WithTimestamps() EqualOption {
+	return func(c *equalConfig) {
+		c.compareTimestamps = true
+	}
+}
+
+// Equal reports whether two events are semantically equal: they have the
+// same concrete type and their JSON forms match after normalizing key order
+// and numeric formatting. Timestamps are ignored unless WithTimestamps is
+// given.
+func Equal(a, b Event, options ...EqualOption) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	cfg := &equalConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	aValue, err := normalizedEventJSON(a, !cfg.compareTimestamps)
+	if err != nil {
+		return false
+	}
+	bValue, err := normalizedEventJSON(b, !cfg.compareTimestamps)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// TestingT is the subset of *testing.T used by AssertJSONEqual
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertJSONEqual checks that event serializes to the same JSON as expected,
+// ignoring key order and numeric formatting (1, 1.0 and 1e0 are equal). If
+// expected has no "timestamp" field, the event's timestamp is ignored, so
+// golden fixtures shared with other SDKs need not pin one. It reports a
+// failure through t and returns whether the values matched.
+func AssertJSONEqual(t TestingT, expected []byte, event Event) bool {
+	t.Helper()
+
+	if event == nil {
+		t.Errorf("AssertJSONEqual: event is nil")
+		return false
+	}
+
+	want, err := normalizeJSON(expected)
+	if err != nil {
+		t.Errorf("AssertJSONEqual: invalid expected JSON: %v", err)
+		return false
+	}
+
+	hasTimestamp := false
+	if obj, ok := want.(map[string]any); ok {
+		_, hasTimestamp = obj["timestamp"]
+	}
+	got, err := normalizedEventJSON(event, !hasTimestamp)
+	if err != nil {
+		t.Errorf("AssertJSONEqual: failed to serialize %s event: %v", event.Type(), err)
+		return false
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		// Re-marshal both sides so the failure shows sorted, comparable JSON
+		wantJSON, _ := json.Marshal(want)
+		gotJSON, _ := json.Marshal(got)
+		t.Errorf("AssertJSONEqual: %s event does not match expected JSON\nexpected: %s\n  actual: %s", event.Type(), wantJSON, gotJSON)
+		return false
+	}
+
+	return true
+}
+
+// normalizedEventJSON returns the normalized ToJSON form of an event,
+// optionally without its timestamp
+func normalizedEventJSON(event Event, dropTimestamp bool) (any, error) {
+	data, err := event.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := normalizeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if dropTimestamp {
+		if obj, ok := value.(map[string]any); ok {
+			delete(obj, "timestamp")
+		}
+	}
+
+	return value, nil
+}
+
+// maxNormalizedExponent bounds the decimal exponent of numbers normalizeJSON
+// converts to rational form. big.Rat expands the exponent in full, so
+// unbounded inputs such as 1e999999999 would take unbounded time and memory.
+const maxNormalizedExponent = 10000
+
+// normalizedNumber is a JSON number in exact rational form. It is a distinct
+// type so that a normalized number never equals a string with the same text.
+type normalizedNumber string
+
+// normalizeJSON decodes data, which must hold a single JSON value, replacing
+// every number with its exact rational form so that equal values compare
+// equal regardless of formatting
+func normalizeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+
+	return normalizeNumbers(value)
+}
+
+// normalizeNumber returns the exact rational form of a JSON number
+func normalizeNumber(number json.Number) (normalizedNumber, error) {
+	text := number.String()
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return normalizedNumber(strconv.FormatInt(i, 10)), nil
+	}
+
+	if idx := strings.IndexAny(text, "eE"); idx >= 0 {
+		exponent, err := strconv.Atoi(text[idx+1:])
+		if err != nil || exponent > maxNormalizedExponent || exponent < -maxNormalizedExponent {
+			return "", fmt.Errorf("number %q exponent is out of range", text)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return "", fmt.Errorf("invalid number %q", text)
+	}
+	return normalizedNumber(r.RatString()), nil
+}
+
+func normalizeNumbers(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return normalizeNumber(v)
+	case map[string]any:
+		for key, item := range v {
+			normalized, err := normalizeNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = normalized
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			normalized, err := normalizeNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = normalized
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
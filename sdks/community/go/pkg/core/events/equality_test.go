@@ -0,0 +1,122 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT captures AssertJSONEqual failures without failing the test
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestEqual(t *testing.T) {
+	a := NewTextMessageContentEvent("msg-1", "hello")
+	a.SetTimestamp(1000)
+	b := NewTextMessageContentEvent("msg-1", "hello")
+	b.SetTimestamp(2000)
+
+	t.Run("ignores timestamps by default", func(t *testing.T) {
+		assert.True(t, Equal(a, b))
+		assert.False(t, Equal(a, b, WithTimestamps()))
+
+		b.SetTimestamp(1000)
+		defer b.SetTimestamp(2000)
+		assert.True(t, Equal(a, b, WithTimestamps()))
+	})
+
+	t.Run("detects field differences", func(t *testing.T) {
+		assert.False(t, Equal(a, NewTextMessageContentEvent("msg-1", "goodbye")))
+		assert.False(t, Equal(a, NewTextMessageContentEvent("msg-2", "hello")))
+	})
+
+	t.Run("requires the same concrete type", func(t *testing.T) {
+		assert.False(t, Equal(NewThinkingStartEvent(), NewThinkingEndEvent()))
+		assert.False(t, Equal(NewTextMessageEndEvent("msg-1"), NewToolCallEndEvent("msg-1")))
+	})
+
+	t.Run("normalizes numbers in payloads", func(t *testing.T) {
+		intValue := NewStateSnapshotEvent(map[string]any{"count": 1, "ratio": 0.5})
+		floatValue := NewStateSnapshotEvent(map[string]any{"count": 1.0, "ratio": float32(0.5)})
+		assert.True(t, Equal(intValue, floatValue))
+
+		stringValue := NewStateSnapshotEvent(map[string]any{"count": "1", "ratio": 0.5})
+		assert.False(t, Equal(intValue, stringValue))
+
+		exponent := NewStateSnapshotEvent(map[string]any{"count": json.Number("1e0"), "ratio": json.Number("5e-1")})
+		assert.True(t, Equal(intValue, exponent))
+	})
+
+	t.Run("nil handling", func(t *testing.T) {
+		assert.True(t, Equal(nil, nil))
+		assert.False(t, Equal(a, nil))
+		assert.False(t, Equal(nil, a))
+	})
+}
+
+func TestAssertJSONEqual(t *testing.T) {
+	event := NewCustomEvent("metrics", WithValue(map[string]any{"latency": 12, "tags": []any{"a", "b"}}))
+	event.SetTimestamp(1700000000000)
+
+	t.Run("matches golden JSON regardless of key order and number format", func(t *testing.T) {
+		golden := []byte(`{
+			"value": {"tags": ["a", "b"], "latency": 12.0},
+			"name": "metrics",
+			"type": "CUSTOM"
+		}`)
+		assert.True(t, AssertJSONEqual(t, golden, event))
+	})
+
+	t.Run("compares timestamps when the fixture has one", func(t *testing.T) {
+		rt := &recordingT{}
+		golden := []byte(`{"type":"CUSTOM","timestamp":1,"name":"metrics","value":{"latency":12,"tags":["a","b"]}}`)
+		assert.False(t, AssertJSONEqual(rt, golden, event))
+		assert.Len(t, rt.errors, 1)
+
+		golden = []byte(`{"type":"CUSTOM","timestamp":1.7e12,"name":"metrics","value":{"latency":12,"tags":["a","b"]}}`)
+		assert.True(t, AssertJSONEqual(t, golden, event))
+	})
+
+	t.Run("reports mismatches", func(t *testing.T) {
+		rt := &recordingT{}
+		assert.False(t, AssertJSONEqual(rt, []byte(`{"type":"CUSTOM","name":"other"}`), event))
+		if assert.Len(t, rt.errors, 1) {
+			assert.Contains(t, rt.errors[0], "does not match expected JSON")
+		}
+	})
+
+	t.Run("reports invalid fixtures", func(t *testing.T) {
+		rt := &recordingT{}
+		assert.False(t, AssertJSONEqual(rt, []byte(`{not json`), event))
+		if assert.Len(t, rt.errors, 1) {
+			assert.Contains(t, rt.errors[0], "invalid expected JSON")
+		}
+	})
+
+	t.Run("rejects trailing data in fixtures", func(t *testing.T) {
+		rt := &recordingT{}
+		golden := []byte(`{"type":"CUSTOM","name":"metrics","value":{"latency":12,"tags":["a","b"]}} {"type":"CUSTOM"}`)
+		assert.False(t, AssertJSONEqual(rt, golden, event))
+		if assert.Len(t, rt.errors, 1) {
+			assert.Contains(t, rt.errors[0], "unexpected data after JSON value")
+		}
+	})
+
+	t.Run("rejects numbers with huge exponents", func(t *testing.T) {
+		rt := &recordingT{}
+		golden := []byte(`{"type":"CUSTOM","name":"metrics","value":{"latency":1e999999999,"tags":["a","b"]}}`)
+		assert.False(t, AssertJSONEqual(rt, golden, event))
+		if assert.Len(t, rt.errors, 1) {
+			assert.Contains(t, rt.errors[0], "exponent is out of range")
+		}
+	})
+}
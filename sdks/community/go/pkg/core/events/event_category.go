@@ -0,0 +1,108 @@
+package events
+
+// Category groups event types by the part of the protocol they belong to
+type Category string
+
+// Event categories follow the grouping used in the AG-UI protocol
+// specification.
+const (
+	// CategoryLifecycle covers run and step lifecycle events.
+	CategoryLifecycle Category = "lifecycle"
+	// CategoryMessage covers text message streaming events.
+	CategoryMessage Category = "message"
+	// CategoryTool covers tool call streaming and result events.
+	CategoryTool Category = "tool"
+	// CategoryState covers state and messages snapshots and deltas.
+	CategoryState Category = "state"
+	// CategoryActivity covers activity snapshot and delta events.
+	CategoryActivity Category = "activity"
+	// CategoryReasoning covers reasoning events and the deprecated thinking events.
+	CategoryReasoning Category = "reasoning"
+	// CategorySpecial covers raw and custom events.
+	CategorySpecial Category = "special"
+	// CategoryUnknown is returned for unrecognized event types.
+	CategoryUnknown Category = "unknown"
+)
+
+// eventCategories maps every valid event type to its category. It is also
+// the list of valid event types: a type without a category is rejected.
+var eventCategories = map[EventType]Category{
+	EventTypeRunStarted:   CategoryLifecycle,
+	EventTypeRunFinished:  CategoryLifecycle,
+	EventTypeRunError:     CategoryLifecycle,
+	EventTypeStepStarted:  CategoryLifecycle,
+	EventTypeStepFinished: CategoryLifecycle,
+
+	EventTypeTextMessageStart:   CategoryMessage,
+	EventTypeTextMessageContent: CategoryMessage,
+	EventTypeTextMessageEnd:     CategoryMessage,
+	EventTypeTextMessageChunk:   CategoryMessage,
+
+	EventTypeToolCallStart:  CategoryTool,
+	EventTypeToolCallArgs:   CategoryTool,
+	EventTypeToolCallEnd:    CategoryTool,
+	EventTypeToolCallChunk:  CategoryTool,
+	EventTypeToolCallResult: CategoryTool,
+
+	EventTypeStateSnapshot:    CategoryState,
+	EventTypeStateDelta:       CategoryState,
+	EventTypeMessagesSnapshot: CategoryState,
+
+	EventTypeActivitySnapshot: CategoryActivity,
+	EventTypeActivityDelta:    CategoryActivity,
+
+	EventTypeReasoningStart:             CategoryReasoning,
+	EventTypeReasoningMessageStart:      CategoryReasoning,
+	EventTypeReasoningMessageContent:    CategoryReasoning,
+	EventTypeReasoningMessageEnd:        CategoryReasoning,
+	EventTypeReasoningMessageChunk:      CategoryReasoning,
+	EventTypeReasoningEnd:               CategoryReasoning,
+	EventTypeReasoningEncryptedValue:    CategoryReasoning,
+	EventTypeThinkingStart:              CategoryReasoning,
+	EventTypeThinkingEnd:                CategoryReasoning,
+	EventTypeThinkingTextMessageStart:   CategoryReasoning,
+	EventTypeThinkingTextMessageContent: CategoryReasoning,
+	EventTypeThinkingTextMessageEnd:     CategoryReasoning,
+
+	EventTypeRaw:    CategorySpecial,
+	EventTypeCustom: CategorySpecial,
+}
+
+// Category returns the protocol category of the event type, or
+// CategoryUnknown if the type is not recognized
+func (t EventType) Category() Category {
+	if category, ok := eventCategories[t]; ok {
+		return category
+	}
+	return CategoryUnknown
+}
+
+// IsRunLifecycle reports whether the type is a run or step lifecycle event
+func (t EventType) IsRunLifecycle() bool {
+	return t.Category() == CategoryLifecycle
+}
+
+// IsMessage reports whether the type is a text message event
+func (t EventType) IsMessage() bool {
+	return t.Category() == CategoryMessage
+}
+
+// IsTool reports whether the type is a tool call event
+func (t EventType) IsTool() bool {
+	return t.Category() == CategoryTool
+}
+
+// IsState reports whether the type is a state or messages snapshot or delta
+func (t EventType) IsState() bool {
+	return t.Category() == CategoryState
+}
+
+// IsActivity reports whether the type is an activity event
+func (t EventType) IsActivity() bool {
+	return t.Category() == CategoryActivity
+}
+
+// IsReasoning reports whether the type is a reasoning or thinking event
+func (t EventType) IsReasoning() bool {
+	return t.Category() == CategoryReasoning
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTypeCategory(t *testing.T) {
+	t.Run("categorized types are valid", func(t *testing.T) {
+		for eventType := range eventCategories {
+			assert.True(t, isValidEventType(eventType), "event type %s", eventType)
+		}
+		assert.False(t, isValidEventType(EventTypeUnknown))
+	})
+
+	tests := []struct {
+		eventType EventType
+		category  Category
+		check     func(EventType) bool
+	}{
+		{EventTypeRunStarted, CategoryLifecycle, EventType.IsRunLifecycle},
+		{EventTypeStepFinished, CategoryLifecycle, EventType.IsRunLifecycle},
+		{EventTypeTextMessageChunk, CategoryMessage, EventType.IsMessage},
+		{EventTypeToolCallResult, CategoryTool, EventType.IsTool},
+		{EventTypeStateDelta, CategoryState, EventType.IsState},
+		{EventTypeMessagesSnapshot, CategoryState, EventType.IsState},
+		{EventTypeActivityDelta, CategoryActivity, EventType.IsActivity},
+		{EventTypeReasoningEncryptedValue, CategoryReasoning, EventType.IsReasoning},
+		{EventTypeThinkingTextMessageContent, CategoryReasoning, EventType.IsReasoning},
+		{EventTypeCustom, CategorySpecial, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			assert.Equal(t, tt.category, tt.eventType.Category())
+			if tt.check != nil {
+				assert.True(t, tt.check(tt.eventType))
+			}
+		})
+	}
+
+	t.Run("predicates are exclusive", func(t *testing.T) {
+		assert.False(t, EventTypeToolCallStart.IsMessage())
+		assert.False(t, EventTypeTextMessageStart.IsTool())
+		assert.False(t, EventTypeReasoningMessageStart.IsMessage())
+		assert.False(t, EventTypeRunError.IsState())
+	})
+
+	t.Run("unknown types", func(t *testing.T) {
+		assert.Equal(t, CategoryUnknown, EventType("FUTURE_EVENT").Category())
+		assert.Equal(t, CategoryUnknown, EventTypeUnknown.Category())
+		assert.False(t, EventType("").IsRunLifecycle())
+	})
+}
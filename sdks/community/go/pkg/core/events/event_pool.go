@@ -0,0 +1,107 @@
+package events
+
+import "sync"
+
+// Pooled events trade a little API safety for fewer allocations on hot
+// streaming paths. An event obtained from one of the Acquire functions is
+// owned by the caller until it is passed to Release; after Release the event
+// (including its BaseEvent) may be handed out again, so
+// callers must not read, modify or retain it. In particular, do not Release an
+// event that is still referenced by a channel, buffer or another goroutine.
+//
+// The regular New* constructors remain the right choice for anything that is
+// not allocation-sensitive.
+
+var textMessageContentEventPool = sync.Pool{
+	New: func() any {
+		return &TextMessageContentEvent{BaseEvent: &BaseEvent{}}
+	},
+}
+
+var toolCallArgsEventPool = sync.Pool{
+	New: func() any {
+		return &ToolCallArgsEvent{BaseEvent: &BaseEvent{}}
+	},
+}
+
+var reasoningMessageContentEventPool = sync.Pool{
+	New: func() any {
+		return &ReasoningMessageContentEvent{BaseEvent: &BaseEvent{}}
+	},
+}
+
+// AcquireTextMessageContentEvent returns a pooled text message content event
+// initialized like NewTextMessageContentEvent. Return it with Release once it
+// has been fully consumed.
+func AcquireTextMessageContentEvent(messageID, delta string) *TextMessageContentEvent {
+	e := textMessageContentEventPool.Get().(*TextMessageContentEvent)
+	initPooledBase(e.BaseEvent, EventTypeTextMessageContent)
+	e.MessageID = messageID
+	e.Delta = delta
+	return e
+}
+
+// AcquireToolCallArgsEvent returns a pooled tool call args event initialized
+// like NewToolCallArgsEvent. Return it with Release once it has been fully
+// consumed.
+func AcquireToolCallArgsEvent(toolCallID, delta string) *ToolCallArgsEvent {
+	e := toolCallArgsEventPool.Get().(*ToolCallArgsEvent)
+	initPooledBase(e.BaseEvent, EventTypeToolCallArgs)
+	e.ToolCallID = toolCallID
+	e.Delta = delta
+	return e
+}
+
+// AcquireReasoningMessageContentEvent returns a pooled reasoning message
+// content event initialized like NewReasoningMessageContentEvent. Return it
+// with Release once it has been fully consumed.
+func AcquireReasoningMessageContentEvent(messageID, delta string) *ReasoningMessageContentEvent {
+	e := reasoningMessageContentEventPool.Get().(*ReasoningMessageContentEvent)
+	initPooledBase(e.BaseEvent, EventTypeReasoningMessageContent)
+	e.MessageID = messageID
+	e.Delta = delta
+	return e
+}
+
+// Release returns an event obtained from one of the Acquire functions to its
+// pool. Events of other types, and events whose BaseEvent is nil, are
+// ignored, so Release is safe to call on any event. Releasing an event built
+// by a New* constructor simply donates it to the pool. Either way the caller
+// must not use the event after calling Release.
+func Release(event Event) {
+	switch e := event.(type) {
+	case *TextMessageContentEvent:
+		if e == nil || e.BaseEvent == nil {
+			return
+		}
+		e.MessageID = ""
+		e.Delta = ""
+		*e.BaseEvent = BaseEvent{}
+		textMessageContentEventPool.Put(e)
+	case *ToolCallArgsEvent:
+		if e == nil || e.BaseEvent == nil {
+			return
+		}
+		e.ToolCallID = ""
+		e.Delta = ""
+		*e.BaseEvent = BaseEvent{}
+		toolCallArgsEventPool.Put(e)
+	case *ReasoningMessageContentEvent:
+		if e == nil || e.BaseEvent == nil {
+			return
+		}
+		e.MessageID = ""
+		e.Delta = ""
+		*e.BaseEvent = BaseEvent{}
+		reasoningMessageContentEventPool.Put(e)
+	}
+}
+
+// initPooledBase prepares a base event that Release has already cleared. The
+// timestamp gets fresh storage rather than being written through the old
+// pointer, which the previous owner may have replaced with one it still uses.
+func initPooledBase(b *BaseEvent, eventType EventType) {
+	timestamp := clockNow().UnixMilli()
+	b.EventType = eventType
+	b.TimestampMs = &timestamp
+}
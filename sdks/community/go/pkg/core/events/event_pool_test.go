@@ -0,0 +1,128 @@
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventPool(t *testing.T) {
+	t.Run("AcquireTextMessageContentEvent", func(t *testing.T) {
+		event := AcquireTextMessageContentEvent("msg-1", "hello")
+		defer Release(event)
+
+		assert.Equal(t, EventTypeTextMessageContent, event.Type())
+		assert.Equal(t, "msg-1", event.MessageID)
+		assert.Equal(t, "hello", event.Delta)
+		require.NotNil(t, event.Timestamp())
+		assert.Positive(t, *event.Timestamp())
+		assert.NoError(t, event.Validate())
+
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"type":"TEXT_MESSAGE_CONTENT","timestamp":%d,"messageId":"msg-1","delta":"hello"}`, *event.Timestamp()), string(data))
+	})
+
+	t.Run("AcquireToolCallArgsEvent", func(t *testing.T) {
+		event := AcquireToolCallArgsEvent("tool-1", `{"a":`)
+		defer Release(event)
+
+		assert.Equal(t, EventTypeToolCallArgs, event.Type())
+		assert.Equal(t, "tool-1", event.ToolCallID)
+		assert.Equal(t, `{"a":`, event.Delta)
+		assert.NoError(t, event.Validate())
+	})
+
+	t.Run("AcquireReasoningMessageContentEvent", func(t *testing.T) {
+		event := AcquireReasoningMessageContentEvent("msg-2", "thinking")
+		defer Release(event)
+
+		assert.Equal(t, EventTypeReasoningMessageContent, event.Type())
+		assert.Equal(t, "msg-2", event.MessageID)
+		assert.Equal(t, "thinking", event.Delta)
+		assert.NoError(t, event.Validate())
+	})
+
+	t.Run("ReleasedEventsAreReset", func(t *testing.T) {
+		event := AcquireTextMessageContentEvent("msg-1", "hello")
+		event.RawEvent = map[string]any{"provider": "test"}
+		Release(event)
+
+		// sync.Pool may or may not hand back the same instance, but whatever it
+		// returns must carry only the newly supplied values.
+		next := AcquireTextMessageContentEvent("msg-2", "world")
+		defer Release(next)
+		assert.Equal(t, "msg-2", next.MessageID)
+		assert.Equal(t, "world", next.Delta)
+		assert.Nil(t, next.RawEvent)
+		assert.Equal(t, EventTypeTextMessageContent, next.Type())
+	})
+
+	t.Run("CallerTimestampsAreNotOverwritten", func(t *testing.T) {
+		shared := int64(42)
+		event := AcquireToolCallArgsEvent("tool-1", "{}")
+		event.TimestampMs = &shared
+		Release(event)
+
+		next := AcquireToolCallArgsEvent("tool-2", "{}")
+		defer Release(next)
+		assert.Equal(t, int64(42), shared)
+		assert.NotSame(t, &shared, next.TimestampMs)
+	})
+
+	t.Run("ReleaseIgnoresUnpooledTypes", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			Release(NewRunStartedEvent("thread-1", "run-1"))
+			Release(&TextMessageContentEvent{})
+			Release((*ToolCallArgsEvent)(nil))
+			Release(nil)
+		})
+	})
+}
+
+// The benchmarks below model a streaming loop where each content event is
+// built, inspected and discarded before the next token arrives.
+
+func BenchmarkTextMessageContentEvent_New(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		event := NewTextMessageContentEvent("msg-1", "token")
+		if err := event.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTextMessageContentEvent_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		event := AcquireTextMessageContentEvent("msg-1", "token")
+		if err := event.Validate(); err != nil {
+			b.Fatal(err)
+		}
+		Release(event)
+	}
+}
+
+func BenchmarkToolCallArgsEvent_New(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		event := NewToolCallArgsEvent("tool-1", `{"q":`)
+		if err := event.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToolCallArgsEvent_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		event := AcquireToolCallArgsEvent("tool-1", `{"q":`)
+		if err := event.Validate(); err != nil {
+			b.Fatal(err)
+		}
+		Release(event)
+	}
+}
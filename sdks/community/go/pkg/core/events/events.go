@@ -1,9 +1,9 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 )
 
 // EventType represents the type of AG-UI event.
@@ -71,43 +71,6 @@ const (
 	EventTypeUnknown EventType = "UNKNOWN"
 )
 
-// validEventTypes is a map for O(1) lookup of valid event types
-var validEventTypes = map[EventType]bool{
-	EventTypeTextMessageStart:           true,
-	EventTypeTextMessageContent:         true,
-	EventTypeTextMessageEnd:             true,
-	EventTypeTextMessageChunk:           true,
-	EventTypeToolCallStart:              true,
-	EventTypeToolCallArgs:               true,
-	EventTypeToolCallEnd:                true,
-	EventTypeToolCallChunk:              true,
-	EventTypeToolCallResult:             true,
-	EventTypeStateSnapshot:              true,
-	EventTypeStateDelta:                 true,
-	EventTypeMessagesSnapshot:           true,
-	EventTypeActivitySnapshot:           true,
-	EventTypeActivityDelta:              true,
-	EventTypeRaw:                        true,
-	EventTypeCustom:                     true,
-	EventTypeRunStarted:                 true,
-	EventTypeRunFinished:                true,
-	EventTypeRunError:                   true,
-	EventTypeStepStarted:                true,
-	EventTypeStepFinished:               true,
-	EventTypeThinkingStart:              true,
-	EventTypeThinkingEnd:                true,
-	EventTypeThinkingTextMessageStart:   true,
-	EventTypeThinkingTextMessageContent: true,
-	EventTypeThinkingTextMessageEnd:     true,
-	EventTypeReasoningStart:             true,
-	EventTypeReasoningMessageStart:      true,
-	EventTypeReasoningMessageContent:    true,
-	EventTypeReasoningMessageEnd:        true,
-	EventTypeReasoningMessageChunk:      true,
-	EventTypeReasoningEnd:               true,
-	EventTypeReasoningEncryptedValue:    true,
-}
-
 // Event defines the common interface for all AG-UI events
 type Event interface {
 	// Type returns the event type
@@ -140,6 +103,15 @@ type BaseEvent struct {
 	EventType   EventType `json:"type"`
 	TimestampMs *int64    `json:"timestamp,omitempty"`
 	RawEvent    any       `json:"rawEvent,omitempty"`
+
+	// TraceID and SpanID correlate the event with a distributed trace. They
+	// are optional and omitted from JSON when empty; see WithTraceContext.
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+
+	// Sequence is an optional number, increasing within a run, that lets
+	// consumers drop replayed events; see WithSequence and SequenceGuard.
+	Sequence *uint64 `json:"sequence,omitempty"`
 }
 
 // Type returns the event type
@@ -163,7 +135,7 @@ func (b *BaseEvent) ID() string {
 	if b.TimestampMs != nil {
 		return fmt.Sprintf("%s_%d", b.EventType, *b.TimestampMs)
 	}
-	return fmt.Sprintf("%s_%d", b.EventType, time.Now().UnixMilli())
+	return fmt.Sprintf("%s_%d", b.EventType, clockNow().UnixMilli())
 }
 
 // ToJSON serializes the base event to JSON
@@ -180,6 +152,18 @@ func (b *BaseEvent) ToJSON() ([]byte, error) {
 		eventData["data"] = b.RawEvent
 	}
 
+	if b.TraceID != "" {
+		eventData["traceId"] = b.TraceID
+	}
+
+	if b.SpanID != "" {
+		eventData["spanId"] = b.SpanID
+	}
+
+	if b.Sequence != nil {
+		eventData["sequence"] = *b.Sequence
+	}
+
 	return json.Marshal(eventData)
 }
 
@@ -200,7 +184,7 @@ func (b *BaseEvent) RunID() string {
 
 // NewBaseEvent creates a new base event with the given type and current timestamp
 func NewBaseEvent(eventType EventType) *BaseEvent {
-	now := time.Now().UnixMilli()
+	now := clockNow().UnixMilli()
 	return &BaseEvent{
 		EventType:   eventType,
 		TimestampMs: &now,
@@ -220,210 +204,47 @@ func (b *BaseEvent) Validate() error {
 	return nil
 }
 
-// isValidEventType checks if the given event type is valid
+// isValidEventType checks if the given event type is valid, that is, has a
+// category
 func isValidEventType(eventType EventType) bool {
-	return validEventTypes[eventType]
+	return eventType.Category() != CategoryUnknown
 }
 
 // ValidateSequence validates a sequence of events according to AG-UI protocol rules
 func ValidateSequence(events []Event) error {
-	if len(events) == 0 {
-		return nil
-	}
-
-	// Track active runs, messages, tool calls, and steps
-	activeRuns := make(map[string]bool)
-	activeMessages := make(map[string]bool)
-	activeReasoningMessages := make(map[string]bool)
-	activeToolCalls := make(map[string]bool)
-	activeSteps := make(map[string]bool)
-	finishedRuns := make(map[string]bool)
-
-	for i, event := range events {
-		if err := event.Validate(); err != nil {
-			return fmt.Errorf("event %d validation failed: %w", i, err)
-		}
-
-		// Check sequence-specific validation rules
-		switch event.Type() {
-		case EventTypeRunStarted:
-			if runEvent, ok := event.(*RunStartedEvent); ok {
-				if activeRuns[runEvent.RunID()] {
-					return fmt.Errorf("run %s already started", runEvent.RunID())
-				}
-				if finishedRuns[runEvent.RunID()] {
-					return fmt.Errorf("cannot restart finished run %s", runEvent.RunID())
-				}
-				activeRuns[runEvent.RunID()] = true
-			}
-
-		case EventTypeRunFinished:
-			if runEvent, ok := event.(*RunFinishedEvent); ok {
-				if !activeRuns[runEvent.RunID()] {
-					return fmt.Errorf("cannot finish run %s that was not started", runEvent.RunID())
-				}
-				delete(activeRuns, runEvent.RunID())
-				finishedRuns[runEvent.RunID()] = true
-			}
-
-		case EventTypeRunError:
-			if runEvent, ok := event.(*RunErrorEvent); ok {
-				if runEvent.RunID() != "" && !activeRuns[runEvent.RunID()] {
-					return fmt.Errorf("cannot error run %s that was not started", runEvent.RunID())
-				}
-				if runEvent.RunID() != "" {
-					delete(activeRuns, runEvent.RunID())
-					finishedRuns[runEvent.RunID()] = true
-				}
-			}
-
-		case EventTypeStepStarted:
-			if stepEvent, ok := event.(*StepStartedEvent); ok {
-				if activeSteps[stepEvent.StepName] {
-					return fmt.Errorf("step %s already started", stepEvent.StepName)
-				}
-				activeSteps[stepEvent.StepName] = true
-			}
-
-		case EventTypeStepFinished:
-			if stepEvent, ok := event.(*StepFinishedEvent); ok {
-				if !activeSteps[stepEvent.StepName] {
-					return fmt.Errorf("cannot finish step %s that was not started", stepEvent.StepName)
-				}
-				delete(activeSteps, stepEvent.StepName)
-			}
-
-		case EventTypeTextMessageStart:
-			if msgEvent, ok := event.(*TextMessageStartEvent); ok {
-				if activeMessages[msgEvent.MessageID] {
-					return fmt.Errorf("message %s already started", msgEvent.MessageID)
-				}
-				activeMessages[msgEvent.MessageID] = true
-			}
-
-		case EventTypeTextMessageContent:
-			if msgEvent, ok := event.(*TextMessageContentEvent); ok {
-				if !activeMessages[msgEvent.MessageID] {
-					return fmt.Errorf("cannot add content to message %s that was not started", msgEvent.MessageID)
-				}
-				// Content events are valid between start and end
-			}
-
-		case EventTypeTextMessageEnd:
-			if msgEvent, ok := event.(*TextMessageEndEvent); ok {
-				if !activeMessages[msgEvent.MessageID] {
-					return fmt.Errorf("cannot end message %s that was not started", msgEvent.MessageID)
-				}
-				delete(activeMessages, msgEvent.MessageID)
-			}
-
-		case EventTypeTextMessageChunk:
-			// Chunk events are always valid in sequence context.
-
-		case EventTypeToolCallStart:
-			if toolEvent, ok := event.(*ToolCallStartEvent); ok {
-				if activeToolCalls[toolEvent.ToolCallID] {
-					return fmt.Errorf("tool call %s already started", toolEvent.ToolCallID)
-				}
-				activeToolCalls[toolEvent.ToolCallID] = true
-			}
-
-		case EventTypeToolCallArgs:
-			if toolEvent, ok := event.(*ToolCallArgsEvent); ok {
-				if !activeToolCalls[toolEvent.ToolCallID] {
-					return fmt.Errorf("cannot add args to tool call %s that was not started", toolEvent.ToolCallID)
-				}
-				// Args events are valid between start and end
-			}
-
-		case EventTypeToolCallEnd:
-			if toolEvent, ok := event.(*ToolCallEndEvent); ok {
-				if !activeToolCalls[toolEvent.ToolCallID] {
-					return fmt.Errorf("cannot end tool call %s that was not started", toolEvent.ToolCallID)
-				}
-				delete(activeToolCalls, toolEvent.ToolCallID)
-			}
-
-		case EventTypeToolCallChunk:
-			// Chunk events are always valid in sequence context.
-
-		case EventTypeToolCallResult:
-			// Tool call result events are always valid in sequence context.
-
-		case EventTypeThinkingStart, EventTypeThinkingEnd, EventTypeThinkingTextMessageStart, EventTypeThinkingTextMessageContent, EventTypeThinkingTextMessageEnd:
-			// Thinking events are always valid in sequence context.
+	return ValidateSequenceWithConfig(events, DefaultValidationConfig())
+}
 
-		case EventTypeReasoningStart:
-			// Reasoning events are always valid in sequence context.
+// ValidateSequenceWithConfig validates a sequence of events according to
+// AG-UI protocol rules plus the optional rules enabled in config
+func ValidateSequenceWithConfig(events []Event, config ValidationConfig) error {
+	return ValidateSequenceContextWithConfig(context.Background(), events, config)
+}
 
-		case EventTypeReasoningMessageStart:
-			if msgEvent, ok := event.(*ReasoningMessageStartEvent); ok {
-				if activeReasoningMessages[msgEvent.MessageID] {
-					return fmt.Errorf("reasoning message %s already started", msgEvent.MessageID)
-				}
-				activeReasoningMessages[msgEvent.MessageID] = true
-			}
+// sequenceCancelCheckInterval is the number of events validated between
+// checks of the context in ValidateSequenceContext
+const sequenceCancelCheckInterval = 256
 
-		case EventTypeReasoningMessageContent:
-			if msgEvent, ok := event.(*ReasoningMessageContentEvent); ok {
-				if !activeReasoningMessages[msgEvent.MessageID] {
-					return fmt.Errorf("cannot add content to reasoning message %s that was not started", msgEvent.MessageID)
-				}
-			}
+// ValidateSequenceContext is like ValidateSequence but stops once ctx is
+// done, which bounds the time spent on very large, untrusted sequences. When
+// cancelled, the returned error wraps ctx.Err() and reports how many events
+// had been validated without finding a problem.
+func ValidateSequenceContext(ctx context.Context, events []Event) error {
+	return ValidateSequenceContextWithConfig(ctx, events, DefaultValidationConfig())
+}
 
-		case EventTypeReasoningMessageEnd:
-			if msgEvent, ok := event.(*ReasoningMessageEndEvent); ok {
-				if !activeReasoningMessages[msgEvent.MessageID] {
-					return fmt.Errorf("cannot end reasoning message %s that was not started", msgEvent.MessageID)
-				}
-				delete(activeReasoningMessages, msgEvent.MessageID)
+// ValidateSequenceContextWithConfig is like ValidateSequenceWithConfig but
+// stops once ctx is done
+func ValidateSequenceContextWithConfig(ctx context.Context, events []Event, config ValidationConfig) error {
+	validator := NewValidator(config)
+	for i, event := range events {
+		if i%sequenceCancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("sequence validation stopped after %d of %d events: %w", i, len(events), err)
 			}
-
-		case EventTypeReasoningMessageChunk:
-			// Chunk events are always valid in sequence context.
-
-		case EventTypeReasoningEncryptedValue:
-			// Encrypted value events are always valid in sequence context.
-
-		case EventTypeReasoningEnd:
-			// Reasoning events are always valid in sequence context.
-
-		case EventTypeStateSnapshot:
-			// State snapshot events are always valid in sequence context
-			// They represent complete state at any point in time
-			// Additional validation could be added if needed (e.g., frequency limits)
-
-		case EventTypeStateDelta:
-			// State delta events are always valid in sequence context
-			// They represent incremental changes at any point in time
-			// Additional validation could be added if needed (e.g., conflict detection)
-
-		case EventTypeMessagesSnapshot:
-			// Message snapshot events are always valid in sequence context
-			// They represent complete message state at any point in time
-			// Additional validation could be added if needed (e.g., consistency checks)
-
-		case EventTypeActivitySnapshot:
-			// Activity snapshot events are always valid in sequence context
-			// They represent complete activity state at any point in time
-
-		case EventTypeActivityDelta:
-			// Activity delta events are always valid in sequence context
-			// They represent incremental activity changes at any point in time
-
-		case EventTypeRaw:
-			// Raw events are always valid in sequence context
-			// They contain external data that should be passed through
-			// Additional validation could be added via custom validators
-
-		case EventTypeCustom:
-			// Custom events are always valid in sequence context
-			// They contain application-specific data
-			// Additional validation could be added via custom validators
-
-		default:
-			// This should not happen due to prior validation, but add safety check
-			return fmt.Errorf("unknown event type in sequence: %s", event.Type())
+		}
+		if err := validator.ValidateEvent(event); err != nil {
+			return err
 		}
 	}
 
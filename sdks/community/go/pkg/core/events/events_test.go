@@ -511,6 +511,20 @@ func TestStateEvents(t *testing.T) {
 		}
 		event.Messages = invalidMessages
 		assert.Error(t, event.Validate())
+
+		// Tool results must follow the assistant message that made the call
+		result := Message{ID: "result-1", Role: "tool", Content: "sunny", ToolCallID: "tool-1"}
+		event.Messages = []Message{messages[1], result}
+		assert.NoError(t, event.Validate())
+
+		event.Messages = []Message{result, messages[1]}
+		err := event.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "tool message result-1 references tool call tool-1")
+
+		orphan := Message{ID: "result-2", Role: "tool", Content: "sunny", ToolCallID: "tool-2"}
+		event.Messages = []Message{messages[1], orphan}
+		assert.Error(t, event.Validate())
 	})
 }
 
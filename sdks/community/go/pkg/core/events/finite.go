@@ -0,0 +1,111 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrNonFiniteNumber is matched by errors.Is for every NonFiniteNumberError
+var ErrNonFiniteNumber = errors.New("non-finite number")
+
+// maxFiniteCheckDepth bounds the walk over event payloads. It matches the
+// nesting depth at which encoding/json gives up, so cyclic values are left
+// for the marshaler to reject.
+const maxFiniteCheckDepth = 1000
+
+// NonFiniteNumberError reports a NaN or infinite float in an event payload.
+// JSON has no representation for these values, so every codec rejects them
+// instead of silently dropping or rewriting them.
+type NonFiniteNumberError struct {
+	EventType EventType
+	// Path is the JSON Pointer of the offending value within the event
+	Path  string
+	Value float64
+}
+
+func (e *NonFiniteNumberError) Error() string {
+	return fmt.Sprintf("%s event contains non-finite number %v at %s; NaN and Inf cannot be serialized", e.EventType, e.Value, e.Path)
+}
+
+// Is reports whether target is ErrNonFiniteNumber
+func (e *NonFiniteNumberError) Is(target error) bool {
+	return target == ErrNonFiniteNumber
+}
+
+// checkFinite walks value and returns a NonFiniteNumberError for the first
+// NaN or infinite float it finds. Values implementing json.Marshaler are not
+// inspected, since they control their own encoding.
+func checkFinite(eventType EventType, path string, value any) error {
+	return checkFiniteValue(eventType, path, reflect.ValueOf(value), 0)
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func checkFiniteValue(eventType EventType, path string, v reflect.Value, depth int) error {
+	if !v.IsValid() || depth > maxFiniteCheckDepth {
+		return nil
+	}
+
+	if v.Type().Implements(jsonMarshalerType) {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return &NonFiniteNumberError{EventType: eventType, Path: path, Value: f}
+		}
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return checkFiniteValue(eventType, path, v.Elem(), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkFiniteValue(eventType, path+"/"+strconv.Itoa(i), v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if err := checkFiniteValue(eventType, path+"/"+escapePointerToken(key), iter.Value(), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				tagName, _, _ := strings.Cut(tag, ",")
+				if tagName == "-" {
+					continue
+				}
+				if tagName != "" {
+					name = tagName
+				}
+			}
+			if err := checkFiniteValue(eventType, path+"/"+escapePointerToken(name), v.Field(i), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// escapePointerToken escapes a JSON Pointer reference token (RFC 6901)
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
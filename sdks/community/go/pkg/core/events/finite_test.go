@@ -0,0 +1,60 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonFiniteNumbers(t *testing.T) {
+	type metrics struct {
+		Score  float64 `json:"score"`
+		Hidden float64 `json:"-"`
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		path  string
+	}{
+		{"snapshot NaN", NewStateSnapshotEvent(map[string]any{"a": math.NaN()}), "/snapshot/a"},
+		{"snapshot nested Inf", NewStateSnapshotEvent(map[string]any{"a": []any{1.0, math.Inf(1)}}), "/snapshot/a/1"},
+		{"snapshot struct field", NewStateSnapshotEvent(&metrics{Score: math.Inf(-1)}), "/snapshot/score"},
+		{"snapshot escaped key", NewStateSnapshotEvent(map[string]float64{"a/b": math.NaN()}), "/snapshot/a~1b"},
+		{"custom value", NewCustomEvent("metrics", WithValue(math.NaN())), "/value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.event.ToJSON()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrNonFiniteNumber))
+
+			var nonFinite *NonFiniteNumberError
+			require.True(t, errors.As(err, &nonFinite))
+			assert.Equal(t, tt.event.Type(), nonFinite.EventType)
+			assert.Equal(t, tt.path, nonFinite.Path)
+
+			// json.Marshal reports the same error as ToJSON
+			_, err = json.Marshal(tt.event)
+			assert.True(t, errors.Is(err, ErrNonFiniteNumber))
+		})
+	}
+
+	t.Run("finite values and ignored fields serialize", func(t *testing.T) {
+		snapshot := NewStateSnapshotEvent(&metrics{Score: 1.5, Hidden: math.NaN()})
+		data, err := snapshot.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"snapshot":{"score":1.5}`)
+		assert.Contains(t, string(data), `"type":"STATE_SNAPSHOT"`)
+
+		custom := NewCustomEvent("metrics", WithValue(json.RawMessage(`{"n":1}`)))
+		data, err = custom.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"value":{"n":1}`)
+	})
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// generatorWords is the vocabulary used for generated message content
+var generatorWords = []string{
+	"the", "agent", "is", "checking", "results", "for", "your", "request",
+	"and", "will", "summarize", "them", "shortly", "based", "on", "available", "data",
+}
+
+// GeneratorConfig controls the shape and pace of a generated event stream
+type GeneratorConfig struct {
+	// RunsPerSec is the target rate at which runs are emitted. Zero or less
+	// emits runs as fast as the consumer reads them.
+	RunsPerSec float64
+	// MessagesPerRun is the number of assistant messages in each run.
+	// Defaults to 1.
+	MessagesPerRun int
+	// ToolCallProbability is the chance, between 0 and 1, that an assistant
+	// message is followed by a tool call and its result.
+	ToolCallProbability float64
+	// Runs is the number of runs to generate before the stream is closed.
+	// Zero generates runs until the context is cancelled.
+	Runs int
+}
+
+// StreamGenerator produces valid, sequence-correct event streams for load
+// testing frontends and transports. Each run consists of RUN_STARTED, a
+// number of streamed assistant messages optionally followed by tool calls
+// with JSON arguments and results, and RUN_FINISHED. A StreamGenerator is not
+// safe for concurrent use.
+type StreamGenerator struct {
+	config   GeneratorConfig
+	rng      *rand.Rand
+	ids      IDGenerator // nil uses the default ID generator
+	threadID string
+}
+
+// GeneratorOption configures a StreamGenerator
+type GeneratorOption func(*StreamGenerator)
+
+// WithSeed makes the generator deterministic: the shape and content of the
+// stream and all IDs are derived from seed, so two generators with the same
+// seed and configuration produce the same events. Timestamps still come
+// from the clock; install a ManualClock with SetClock for byte-identical
+// output.
+func WithSeed(seed int64) GeneratorOption {
+	return func(g *StreamGenerator) {
+		g.rng = rand.New(rand.NewSource(seed))
+		g.ids = NewSeededIDGenerator(seed)
+	}
+}
+
+// NewStreamGenerator creates a stream generator with the given configuration
+func NewStreamGenerator(config GeneratorConfig, options ...GeneratorOption) *StreamGenerator {
+	if config.MessagesPerRun <= 0 {
+		config.MessagesPerRun = 1
+	}
+	config.ToolCallProbability = min(max(config.ToolCallProbability, 0), 1)
+
+	g := &StreamGenerator{
+		config: config,
+		rng:    rand.New(rand.NewSource(clockNow().UnixNano())),
+	}
+
+	for _, opt := range options {
+		opt(g)
+	}
+
+	g.threadID = g.idGenerator().GenerateThreadID()
+	return g
+}
+
+// Start emits generated runs on the returned channel at the configured rate.
+// The channel is closed once the configured number of runs has been sent or
+// ctx is done.
+func (g *StreamGenerator) Start(ctx context.Context) <-chan Event {
+	out := make(chan Event, 64)
+
+	go func() {
+		defer close(out)
+
+		var ticker *time.Ticker
+		if g.config.RunsPerSec > 0 {
+			// Rates above one run per nanosecond truncate to a zero period,
+			// which NewTicker rejects
+			ticker = time.NewTicker(max(time.Duration(float64(time.Second)/g.config.RunsPerSec), time.Nanosecond))
+			defer ticker.Stop()
+		}
+
+		for run := 0; g.config.Runs == 0 || run < g.config.Runs; run++ {
+			if ticker != nil && run > 0 {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			for _, event := range g.GenerateRun() {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// GenerateRun returns the events of a single generated run
+func (g *StreamGenerator) GenerateRun() []Event {
+	runID := g.idGenerator().GenerateRunID()
+	seq := []Event{NewRunStartedEvent(g.threadID, runID)}
+
+	for i := 0; i < g.config.MessagesPerRun; i++ {
+		messageID := g.idGenerator().GenerateMessageID()
+		seq = append(seq, NewTextMessageStartEvent(messageID, WithRole("assistant")))
+		for _, delta := range g.deltas(g.sentence()) {
+			seq = append(seq, NewTextMessageContentEvent(messageID, delta))
+		}
+		seq = append(seq, NewTextMessageEndEvent(messageID))
+
+		if g.rng.Float64() < g.config.ToolCallProbability {
+			seq = append(seq, g.toolCall(messageID)...)
+		}
+	}
+
+	return append(seq, NewRunFinishedEvent(g.threadID, runID))
+}
+
+// toolCall generates a complete tool call issued by parentMessageID
+func (g *StreamGenerator) toolCall(parentMessageID string) []Event {
+	toolCallID := g.idGenerator().GenerateToolCallID()
+	seq := []Event{NewToolCallStartEvent(toolCallID, "search", WithParentMessageID(parentMessageID))}
+
+	args, _ := json.Marshal(map[string]any{
+		"query": g.sentence(),
+		"limit": 1 + g.rng.Intn(20),
+	})
+	for _, delta := range g.deltas(string(args)) {
+		seq = append(seq, NewToolCallArgsEvent(toolCallID, delta))
+	}
+
+	return append(seq,
+		NewToolCallEndEvent(toolCallID),
+		NewToolCallResultEvent(g.idGenerator().GenerateMessageID(), toolCallID, g.sentence()),
+	)
+}
+
+// idGenerator returns the generator's own ID generator, or the default one
+func (g *StreamGenerator) idGenerator() IDGenerator {
+	if g.ids != nil {
+		return g.ids
+	}
+	return GetDefaultIDGenerator()
+}
+
+// sentence returns a few random words
+func (g *StreamGenerator) sentence() string {
+	words := make([]string, 3+g.rng.Intn(8))
+	for i := range words {
+		words[i] = generatorWords[g.rng.Intn(len(generatorWords))]
+	}
+	return strings.Join(words, " ")
+}
+
+// deltas splits s into one to four non-empty pieces, as a model streaming
+// tokens would
+func (g *StreamGenerator) deltas(s string) []string {
+	pieces := min(1+g.rng.Intn(4), len(s))
+
+	deltas := make([]string, 0, pieces)
+	for i := 0; i < pieces; i++ {
+		end := len(s) * (i + 1) / pieces
+		start := len(s) * i / pieces
+		deltas = append(deltas, s[start:end])
+	}
+	return deltas
+}
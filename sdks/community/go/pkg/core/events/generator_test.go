@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectGenerated(t *testing.T, ch <-chan Event) []Event {
+	t.Helper()
+
+	var seq []Event
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return seq
+			}
+			seq = append(seq, event)
+		case <-timeout:
+			t.Fatal("generator did not close its channel")
+		}
+	}
+}
+
+func countType(seq []Event, eventType EventType) int {
+	n := 0
+	for _, event := range seq {
+		if event.Type() == eventType {
+			n++
+		}
+	}
+	return n
+}
+
+func TestStreamGenerator(t *testing.T) {
+	strict := ValidationConfig{ValidateToolCallArgsJSON: true}
+
+	t.Run("generates valid sequences", func(t *testing.T) {
+		g := NewStreamGenerator(GeneratorConfig{MessagesPerRun: 4, ToolCallProbability: 0.5, Runs: 20})
+		seq := collectGenerated(t, g.Start(context.Background()))
+
+		require.NoError(t, ValidateSequenceWithConfig(seq, strict))
+		assert.Equal(t, 20, countType(seq, EventTypeRunStarted))
+		assert.Equal(t, 20, countType(seq, EventTypeRunFinished))
+		assert.Equal(t, 80, countType(seq, EventTypeTextMessageStart))
+	})
+
+	t.Run("tool call probability bounds", func(t *testing.T) {
+		never := NewStreamGenerator(GeneratorConfig{MessagesPerRun: 5, ToolCallProbability: 0})
+		assert.Zero(t, countType(never.GenerateRun(), EventTypeToolCallStart))
+
+		always := NewStreamGenerator(GeneratorConfig{MessagesPerRun: 5, ToolCallProbability: 1})
+		run := always.GenerateRun()
+		assert.Equal(t, 5, countType(run, EventTypeToolCallStart))
+		assert.Equal(t, 5, countType(run, EventTypeToolCallResult))
+		assert.Len(t, CorrelateToolCalls(run), 5)
+		require.NoError(t, ValidateSequenceWithConfig(run, strict))
+	})
+
+	t.Run("defaults to one message per run", func(t *testing.T) {
+		g := NewStreamGenerator(GeneratorConfig{})
+		assert.Equal(t, 1, countType(g.GenerateRun(), EventTypeTextMessageStart))
+	})
+
+	t.Run("throttles to the target rate", func(t *testing.T) {
+		g := NewStreamGenerator(GeneratorConfig{RunsPerSec: 50, Runs: 3})
+
+		start := time.Now()
+		seq := collectGenerated(t, g.Start(context.Background()))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+		assert.Equal(t, 3, countType(seq, EventTypeRunStarted))
+	})
+
+	t.Run("extreme rates do not panic", func(t *testing.T) {
+		g := NewStreamGenerator(GeneratorConfig{RunsPerSec: 1e12, Runs: 2})
+		seq := collectGenerated(t, g.Start(context.Background()))
+		assert.Equal(t, 2, countType(seq, EventTypeRunStarted))
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		g := NewStreamGenerator(GeneratorConfig{RunsPerSec: 1000})
+		ch := g.Start(ctx)
+
+		<-ch
+		cancel()
+		collectGenerated(t, ch)
+	})
+
+	t.Run("unseeded generators are seeded from the clock", func(t *testing.T) {
+		SetClock(NewManualClock(time.UnixMilli(1700000000000)))
+		t.Cleanup(func() { SetClock(nil) })
+
+		shape := func() []EventType {
+			g := NewStreamGenerator(GeneratorConfig{MessagesPerRun: 3, ToolCallProbability: 0.5})
+			return eventTypes(g.GenerateRun())
+		}
+		assert.Equal(t, shape(), shape())
+	})
+
+	t.Run("WithSeed produces identical output", func(t *testing.T) {
+		SetClock(NewManualClock(time.UnixMilli(1700000000000)))
+		t.Cleanup(func() { SetClock(nil) })
+
+		render := func(seed int64) []byte {
+			g := NewStreamGenerator(GeneratorConfig{MessagesPerRun: 3, ToolCallProbability: 0.5, Runs: 5}, WithSeed(seed))
+			var out []byte
+			for _, event := range collectGenerated(t, g.Start(context.Background())) {
+				data, err := event.ToJSON()
+				require.NoError(t, err)
+				out = append(append(out, data...), '\n')
+			}
+			return out
+		}
+
+		first := render(42)
+		assert.Equal(t, first, render(42))
+		assert.NotEqual(t, first, render(43))
+	})
+}
@@ -2,7 +2,11 @@ package events
 
 import (
 	"fmt"
-	"time"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -95,7 +99,7 @@ func (g *TimestampIDGenerator) GenerateStepID() string {
 
 // generateTimestampID generates a timestamp-based ID with the given type prefix
 func (g *TimestampIDGenerator) generateTimestampID(typePrefix string) string {
-	timestamp := time.Now().UnixMilli()
+	timestamp := clockNow().UnixMilli()
 	shortUUID := uuid.New().String()[:8]
 
 	if g.prefix != "" {
@@ -104,6 +108,55 @@ func (g *TimestampIDGenerator) generateTimestampID(typePrefix string) string {
 	return fmt.Sprintf("%s-%d-%s", typePrefix, timestamp, shortUUID)
 }
 
+// SeededIDGenerator implements IDGenerator using UUIDs drawn from a seeded
+// pseudo-random source, so the same seed always yields the same sequence of
+// IDs. It is meant for reproducible tests and load runs, not for production
+// IDs. A SeededIDGenerator is safe for concurrent use.
+type SeededIDGenerator struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSeededIDGenerator creates an ID generator seeded with seed
+func NewSeededIDGenerator(seed int64) *SeededIDGenerator {
+	return &SeededIDGenerator{rng: rand.New(rand.NewSource(seed))}
+}
+
+// GenerateRunID generates a seeded run ID
+func (g *SeededIDGenerator) GenerateRunID() string {
+	return g.generateSeededID("run")
+}
+
+// GenerateMessageID generates a seeded message ID
+func (g *SeededIDGenerator) GenerateMessageID() string {
+	return g.generateSeededID("msg")
+}
+
+// GenerateToolCallID generates a seeded tool call ID
+func (g *SeededIDGenerator) GenerateToolCallID() string {
+	return g.generateSeededID("tool")
+}
+
+// GenerateThreadID generates a seeded thread ID
+func (g *SeededIDGenerator) GenerateThreadID() string {
+	return g.generateSeededID("thread")
+}
+
+// GenerateStepID generates a seeded step ID
+func (g *SeededIDGenerator) GenerateStepID() string {
+	return g.generateSeededID("step")
+}
+
+// generateSeededID generates a UUID-based ID with the given type prefix
+func (g *SeededIDGenerator) generateSeededID(typePrefix string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Reads from a rand.Rand never fail
+	id, _ := uuid.NewRandomFromReader(g.rng)
+	return fmt.Sprintf("%s-%s", typePrefix, id.String())
+}
+
 // Global default ID generator instance
 var defaultIDGenerator IDGenerator = NewDefaultIDGenerator()
 
@@ -143,3 +196,115 @@ func GenerateThreadID() string {
 func GenerateStepID() string {
 	return defaultIDGenerator.GenerateStepID()
 }
+
+// idField is a thread, run, message or tool call ID carried by an event
+type idField struct {
+	name  string
+	value *string
+}
+
+// eventIDs returns the ID fields set on an event. Optional IDs that are
+// absent are omitted.
+func eventIDs(event Event) []idField {
+	var fields []idField
+	add := func(name string, value *string) {
+		if value != nil {
+			fields = append(fields, idField{name: name, value: value})
+		}
+	}
+
+	switch e := event.(type) {
+	case *RunStartedEvent:
+		add("threadId", &e.ThreadIDValue)
+		add("runId", &e.RunIDValue)
+	case *RunFinishedEvent:
+		add("threadId", &e.ThreadIDValue)
+		add("runId", &e.RunIDValue)
+	case *RunErrorEvent:
+		if e.RunIDValue != "" {
+			add("runId", &e.RunIDValue)
+		}
+	case *TextMessageStartEvent:
+		add("messageId", &e.MessageID)
+	case *TextMessageContentEvent:
+		add("messageId", &e.MessageID)
+	case *TextMessageEndEvent:
+		add("messageId", &e.MessageID)
+	case *TextMessageChunkEvent:
+		add("messageId", e.MessageID)
+	case *ReasoningStartEvent:
+		add("messageId", &e.MessageID)
+	case *ReasoningEndEvent:
+		add("messageId", &e.MessageID)
+	case *ReasoningMessageStartEvent:
+		add("messageId", &e.MessageID)
+	case *ReasoningMessageContentEvent:
+		add("messageId", &e.MessageID)
+	case *ReasoningMessageEndEvent:
+		add("messageId", &e.MessageID)
+	case *ReasoningMessageChunkEvent:
+		add("messageId", e.MessageID)
+	case *ActivitySnapshotEvent:
+		add("messageId", &e.MessageID)
+	case *ActivityDeltaEvent:
+		add("messageId", &e.MessageID)
+	case *ToolCallStartEvent:
+		add("toolCallId", &e.ToolCallID)
+		add("parentMessageId", e.ParentMessageID)
+	case *ToolCallArgsEvent:
+		add("toolCallId", &e.ToolCallID)
+	case *ToolCallEndEvent:
+		add("toolCallId", &e.ToolCallID)
+	case *ToolCallChunkEvent:
+		add("toolCallId", e.ToolCallID)
+		add("parentMessageId", e.ParentMessageID)
+	case *ToolCallResultEvent:
+		add("messageId", &e.MessageID)
+		add("toolCallId", &e.ToolCallID)
+	case *MessagesSnapshotEvent:
+		for i := range e.Messages {
+			message := &e.Messages[i]
+			add("messageId", &message.ID)
+			for j := range message.ToolCalls {
+				add("toolCallId", &message.ToolCalls[j].ID)
+			}
+			if message.ToolCallID != "" {
+				add("toolCallId", &message.ToolCallID)
+			}
+		}
+	}
+	return fields
+}
+
+// NormalizeIDs trims leading and trailing whitespace from the thread, run,
+// message and tool call IDs of an event, including those of the messages in
+// a MESSAGES_SNAPSHOT, in place, and returns the event.
+// Use it on events from producers that pad or wrap their IDs before they
+// are used as keys.
+func NormalizeIDs(event Event) Event {
+	for _, field := range eventIDs(event) {
+		*field.value = strings.TrimSpace(*field.value)
+	}
+	return event
+}
+
+// checkIDFormat reports the first malformed ID of an event. Empty IDs are
+// left to the event's own validation.
+func checkIDFormat(event Event, maxLength int, pattern *regexp.Regexp) error {
+	for _, field := range eventIDs(event) {
+		id := *field.value
+		switch {
+		case id == "":
+			continue
+		case strings.TrimSpace(id) != id:
+			return fmt.Errorf("%s %q has leading or trailing whitespace", field.name, id)
+		case strings.ContainsFunc(id, unicode.IsControl):
+			return fmt.Errorf("%s %q contains control characters", field.name, id)
+		case maxLength > 0 && len(id) > maxLength:
+			return fmt.Errorf("%s %q is longer than %d bytes", field.name, id, maxLength)
+		case pattern != nil && !pattern.MatchString(id):
+			return fmt.Errorf("%s %q does not match pattern %s", field.name, id, pattern)
+		}
+	}
+	return nil
+}
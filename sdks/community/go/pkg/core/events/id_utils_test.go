@@ -191,6 +191,20 @@ func TestTimestampIDGenerator(t *testing.T) {
 	})
 }
 
+func TestSeededIDGenerator(t *testing.T) {
+	a := NewSeededIDGenerator(7)
+	b := NewSeededIDGenerator(7)
+
+	first := []string{a.GenerateThreadID(), a.GenerateRunID(), a.GenerateMessageID(), a.GenerateToolCallID(), a.GenerateStepID()}
+	second := []string{b.GenerateThreadID(), b.GenerateRunID(), b.GenerateMessageID(), b.GenerateToolCallID(), b.GenerateStepID()}
+	assert.Equal(t, first, second)
+
+	assert.True(t, strings.HasPrefix(first[0], "thread-"))
+	assert.True(t, strings.HasPrefix(first[3], "tool-"))
+	assert.NotEqual(t, a.GenerateMessageID(), a.GenerateMessageID())
+	assert.NotEqual(t, first[1], NewSeededIDGenerator(8).GenerateRunID())
+}
+
 func TestGlobalIDGenerator(t *testing.T) {
 	t.Run("GetDefaultIDGenerator", func(t *testing.T) {
 		gen := GetDefaultIDGenerator()
@@ -0,0 +1,333 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchError reports the operation that made a JSON Patch fail
+type PatchError struct {
+	// OpIndex is the index of the failed operation in the patch
+	OpIndex int
+	Op      JSONPatchOperation
+	Reason  string
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("failed to apply operation %d (%s %s): %s", e.OpIndex, e.Op.Op, e.Op.Path, e.Reason)
+}
+
+// ApplyJSONPatch applies JSON Patch operations (RFC 6902) to a document and
+// returns the patched document. The patch is atomic: operations are applied
+// in order to a copy of the document in its generic JSON form (maps, slices,
+// and json.Number for numbers, so large integers keep their precision), and
+// if one fails a *PatchError is returned and no
+// partially patched result is produced. doc itself is never modified, so
+// callers keep their current state by only replacing it on success.
+func ApplyJSONPatch(doc any, ops []JSONPatchOperation) (any, error) {
+	result, err := toJSONValue(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert document: %w", err)
+	}
+
+	for i, op := range ops {
+		result, err = applyJSONPatchOperation(result, op)
+		if err != nil {
+			return nil, &PatchError{OpIndex: i, Op: op, Reason: err.Error()}
+		}
+	}
+
+	return result, nil
+}
+
+// applyJSONPatchOperation applies a single operation and returns the new
+// document root
+func applyJSONPatchOperation(doc any, op JSONPatchOperation) (any, error) {
+	if err := validateJSONPatchOperation(op); err != nil {
+		return nil, err
+	}
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		value, err := toJSONValue(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert value: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return jsonPatchAdd(doc, path, value)
+		case "replace":
+			return jsonPatchReplace(doc, path, value)
+		}
+
+		current, err := jsonPointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonValuesEqual(current, value) {
+			return nil, fmt.Errorf("test failed: value at %q does not match", op.Path)
+		}
+		return doc, nil
+
+	case "remove":
+		return jsonPatchRemove(doc, path)
+
+	case "move", "copy":
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := jsonPointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "copy" {
+			// Copy through JSON so the source and the copy do not share
+			// nested maps or slices
+			if value, err = toJSONValue(value); err != nil {
+				return nil, err
+			}
+			return jsonPatchAdd(doc, path, value)
+		}
+
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move %q into its own child %q", op.From, op.Path)
+		}
+		if doc, err = jsonPatchRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(doc, path, value)
+	}
+
+	return nil, fmt.Errorf("unsupported operation %s", op.Op)
+}
+
+func jsonPatchAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateJSONContainer(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			if token == "-" {
+				return append(c, value), nil
+			}
+			i, err := jsonArrayIndex(token, len(c)+1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], append([]any{value}, c[i:]...)...), nil
+		}
+		return nil, fmt.Errorf("cannot add %q to a %T", token, container)
+	})
+}
+
+func jsonPatchRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the document root")
+	}
+	return updateJSONContainer(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[token]; !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			delete(c, token)
+			return c, nil
+		case []any:
+			i, err := jsonArrayIndex(token, len(c))
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], c[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove %q from a %T", token, container)
+	})
+}
+
+func jsonPatchReplace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateJSONContainer(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[token]; !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := jsonArrayIndex(token, len(c))
+			if err != nil {
+				return nil, err
+			}
+			c[i] = value
+			return c, nil
+		}
+		return nil, fmt.Errorf("cannot replace %q in a %T", token, container)
+	})
+}
+
+// updateJSONContainer walks to the container holding the last token of path,
+// applies update to it and stores the result back into its parent. It
+// returns the new document root.
+func updateJSONContainer(node any, path []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return update(node, path[0])
+	}
+
+	child, err := jsonChild(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateJSONContainer(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := node.(type) {
+	case map[string]any:
+		c[path[0]] = child
+	case []any:
+		i, _ := jsonArrayIndex(path[0], len(c))
+		c[i] = child
+	}
+	return node, nil
+}
+
+// jsonPointerGet returns the value a parsed JSON pointer refers to
+func jsonPointerGet(doc any, path []string) (any, error) {
+	node := doc
+	for _, token := range path {
+		child, err := jsonChild(node, token)
+		if err != nil {
+			return nil, err
+		}
+		node = child
+	}
+	return node, nil
+}
+
+func jsonChild(node any, token string) (any, error) {
+	switch c := node.(type) {
+	case map[string]any:
+		child, ok := c[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q does not exist", token)
+		}
+		return child, nil
+	case []any:
+		i, err := jsonArrayIndex(token, len(c))
+		if err != nil {
+			return nil, err
+		}
+		return c[i], nil
+	}
+	return nil, fmt.Errorf("cannot look up %q in a %T", token, node)
+}
+
+// jsonArrayIndex parses an array index token, which must be below limit
+func jsonArrayIndex(token string, limit int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i >= limit {
+		return 0, fmt.Errorf("array index %d out of bounds", i)
+	}
+	return i, nil
+}
+
+// parseJSONPointer splits a JSON pointer (RFC 6901) into unescaped tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// toJSONValue converts v to the generic value json.Unmarshal produces, with
+// numbers decoded as json.Number
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// jsonValuesEqual compares generic JSON values as RFC 6902 section 4.6
+// requires: numbers are equal when their values are, so 1 and 1.0 match, and
+// arrays and objects are compared element by element
+func jsonValuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if a == b {
+			return true
+		}
+		aValue, aErr := normalizeNumber(a)
+		bValue, bErr := normalizeNumber(b)
+		return aErr == nil && bErr == nil && aValue == bValue
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, item := range a {
+			other, ok := b[key]
+			if !ok || !jsonValuesEqual(item, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonValuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
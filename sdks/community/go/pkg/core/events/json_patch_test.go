@@ -0,0 +1,182 @@
+package events
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := map[string]any{
+		"steps": []any{"plan", "search", "answer"},
+		"user":  map[string]any{"name": "ada", "prefs": map[string]any{"theme": "dark"}},
+	}
+
+	t.Run("add, replace and remove", func(t *testing.T) {
+		result, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "add", Path: "/steps/-", Value: "review"},
+			{Op: "add", Path: "/steps/0", Value: "start"},
+			{Op: "replace", Path: "/user/name", Value: "grace"},
+			{Op: "remove", Path: "/user/prefs"},
+			{Op: "test", Path: "/steps/4", Value: "review"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"steps": []any{"start", "plan", "search", "answer", "review"},
+			"user":  map[string]any{"name": "grace"},
+		}, result)
+	})
+
+	t.Run("move array elements", func(t *testing.T) {
+		result, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "move", From: "/steps/0", Path: "/steps/2"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []any{"search", "answer", "plan"}, result.(map[string]any)["steps"])
+
+		result, err = ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "move", From: "/steps/2", Path: "/steps/0"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []any{"answer", "plan", "search"}, result.(map[string]any)["steps"])
+	})
+
+	t.Run("move between objects", func(t *testing.T) {
+		result, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "move", From: "/user/prefs", Path: "/prefs"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"theme": "dark"}, result.(map[string]any)["prefs"])
+		assert.NotContains(t, result.(map[string]any)["user"], "prefs")
+	})
+
+	t.Run("copy subtrees", func(t *testing.T) {
+		result, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "copy", From: "/user", Path: "/owner"},
+			{Op: "replace", Path: "/owner/prefs/theme", Value: "light"},
+		})
+		require.NoError(t, err)
+
+		patched := result.(map[string]any)
+		assert.Equal(t, map[string]any{"name": "ada", "prefs": map[string]any{"theme": "light"}}, patched["owner"])
+		assert.Equal(t, "dark", patched["user"].(map[string]any)["prefs"].(map[string]any)["theme"], "copy must not share the source subtree")
+	})
+
+	t.Run("escaped pointers", func(t *testing.T) {
+		result, err := ApplyJSONPatch(map[string]any{"a/b": 1, "c~d": 2}, []JSONPatchOperation{
+			{Op: "move", From: "/a~1b", Path: "/c~0d"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"c~d": json.Number("1")}, result)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "add", Path: "/steps/-", Value: "review"},
+			{Op: "move", From: "/user", Path: "/user/prefs/user"},
+		})
+		assert.EqualError(t, err, `failed to apply operation 1 (move /user/prefs/user): cannot move "/user" into its own child "/user/prefs/user"`)
+
+		_, err = ApplyJSONPatch(doc, []JSONPatchOperation{{Op: "copy", From: "/missing", Path: "/x"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `member "missing" does not exist`)
+
+		_, err = ApplyJSONPatch(doc, []JSONPatchOperation{{Op: "remove", Path: "/steps/3"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "array index 3 out of bounds")
+
+		_, err = ApplyJSONPatch(doc, []JSONPatchOperation{{Op: "test", Path: "/user/name", Value: "grace"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `test failed: value at "/user/name" does not match`)
+	})
+
+	t.Run("failed patches leave the document unchanged", func(t *testing.T) {
+		state := map[string]any{"count": float64(1), "items": []any{"a", "b"}, "meta": map[string]any{"v": float64(1)}}
+
+		result, err := ApplyJSONPatch(state, []JSONPatchOperation{
+			{Op: "replace", Path: "/count", Value: 2},
+			{Op: "add", Path: "/items/-", Value: "c"},
+			{Op: "remove", Path: "/meta/v"},
+			{Op: "replace", Path: "/missing/field", Value: true},
+			{Op: "add", Path: "/done", Value: true},
+		})
+		require.Error(t, err)
+		assert.Nil(t, result)
+
+		var patchErr *PatchError
+		require.ErrorAs(t, err, &patchErr)
+		assert.Equal(t, 3, patchErr.OpIndex)
+		assert.Equal(t, "/missing/field", patchErr.Op.Path)
+		assert.Equal(t, `member "missing" does not exist`, patchErr.Reason)
+
+		assert.Equal(t, map[string]any{"count": float64(1), "items": []any{"a", "b"}, "meta": map[string]any{"v": float64(1)}}, state)
+	})
+
+	t.Run("input is not modified", func(t *testing.T) {
+		_, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+			{Op: "remove", Path: "/steps/0"},
+			{Op: "remove", Path: "/user/name"},
+		})
+		require.NoError(t, err)
+		assert.Len(t, doc["steps"], 3)
+		assert.Equal(t, "ada", doc["user"].(map[string]any)["name"])
+	})
+}
+
+func TestApplyJSONPatchLargeIntegers(t *testing.T) {
+	const big = int64(math.MaxInt64 - 1)
+
+	result, err := ApplyJSONPatch(map[string]any{"id": json.Number("9223372036854775806")}, []JSONPatchOperation{
+		{Op: "copy", From: "/id", Path: "/copy"},
+		{Op: "add", Path: "/added", Value: big},
+		{Op: "test", Path: "/id", Value: big},
+	})
+	require.NoError(t, err)
+
+	patched := result.(map[string]any)
+	for _, key := range []string{"id", "copy", "added"} {
+		n, err := patched[key].(json.Number).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, big, n, key)
+	}
+}
+
+func TestApplyJSONPatchTestComparesNumbersByValue(t *testing.T) {
+	doc := map[string]any{
+		"count": json.Number("1"),
+		"items": []any{json.Number("2.50"), map[string]any{"ratio": json.Number("1e-1")}},
+	}
+
+	_, err := ApplyJSONPatch(doc, []JSONPatchOperation{
+		{Op: "test", Path: "/count", Value: 1.0},
+		{Op: "test", Path: "/count", Value: json.Number("1.0")},
+		{Op: "test", Path: "/items", Value: []any{2.5, map[string]any{"ratio": 0.1}}},
+	})
+	require.NoError(t, err)
+
+	for _, value := range []any{2, "1", []any{1}} {
+		_, err := ApplyJSONPatch(doc, []JSONPatchOperation{{Op: "test", Path: "/count", Value: value}})
+		assert.Error(t, err, "%v", value)
+	}
+	_, err = ApplyJSONPatch(doc, []JSONPatchOperation{{Op: "test", Path: "/items", Value: []any{2.5}}})
+	assert.Error(t, err)
+}
+
+func TestJSONPatchFromField(t *testing.T) {
+	for _, op := range []string{"move", "copy"} {
+		assert.NoError(t, NewStateDeltaEvent([]JSONPatchOperation{{Op: op, From: "/a", Path: "/b"}}).Validate())
+		err := NewStateDeltaEvent([]JSONPatchOperation{{Op: op, Path: "/b"}}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "from field is required for "+op)
+	}
+
+	err := NewStateDeltaEvent([]JSONPatchOperation{{Op: "replace", From: "/a", Path: "/b", Value: 1}}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "from field is only valid for move and copy operations, got: replace")
+	err = NewActivityDeltaEvent("activity-1", "PLAN", []JSONPatchOperation{{Op: "remove", From: "/a", Path: "/b"}}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "from field is only valid for move and copy operations")
+}
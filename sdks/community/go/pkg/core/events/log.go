@@ -0,0 +1,296 @@
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultLogCapacity is the number of events a Log keeps by default
+const defaultLogCapacity = 4096
+
+// LogEntry is an event stored in a Log
+type LogEntry struct {
+	// Seq is the position of the event in the log, starting at zero. It
+	// keeps increasing after older entries are evicted.
+	Seq uint64
+	// RunID is the run the event belongs to: the run of the most recent
+	// RUN_STARTED, up to and including its RUN_FINISHED or RUN_ERROR.
+	RunID string
+	// Time is the event timestamp, or the time it was appended if the event
+	// has none.
+	Time  time.Time
+	Event Event
+
+	messageID  string
+	toolCallID string
+}
+
+// Query selects entries from a Log. Zero-valued fields match everything;
+// non-zero fields must all match.
+type Query struct {
+	RunID      string
+	MessageID  string
+	ToolCallID string
+	// Types restricts the result to the given event types
+	Types []EventType
+	// Since excludes entries whose Time is before it
+	Since time.Time
+	// Limit caps the number of entries returned, keeping the most recent.
+	// Zero means no limit.
+	Limit int
+}
+
+// Log is an in-memory, bounded record of events indexed by run, message and
+// tool call ID. It is meant for debugging and test assertions: append every
+// event of a stream and query it afterwards. Once full, the oldest entries
+// are evicted. A Log is safe for concurrent use.
+type Log struct {
+	mu       sync.RWMutex
+	capacity int
+	entries  []LogEntry // ring of at most capacity entries
+	start    int        // index of the oldest entry in entries
+	next     uint64     // sequence number of the next entry
+	runID    string     // run that new events are attributed to
+
+	byRun      map[string][]uint64
+	byMessage  map[string][]uint64
+	byToolCall map[string][]uint64
+
+	now func() time.Time // injectable clock for tests
+}
+
+// LogOption configures a Log
+type LogOption func(*Log)
+
+// WithLogCapacity sets how many events the log keeps. Values below one are
+// ignored.
+func WithLogCapacity(capacity int) LogOption {
+	return func(l *Log) {
+		if capacity > 0 {
+			l.capacity = capacity
+		}
+	}
+}
+
+// NewLog creates an empty event log
+func NewLog(options ...LogOption) *Log {
+	l := &Log{
+		capacity: defaultLogCapacity,
+		now:      clockNow,
+	}
+
+	for _, opt := range options {
+		opt(l)
+	}
+
+	l.Reset()
+	return l
+}
+
+// Append records an event and returns the entry created for it. Nil events
+// are ignored.
+func (l *Log) Append(event Event) LogEntry {
+	if event == nil {
+		return LogEntry{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if started, ok := event.(*RunStartedEvent); ok {
+		l.runID = started.RunID()
+	}
+
+	entry := LogEntry{
+		Seq:   l.next,
+		RunID: l.runID,
+		Time:  l.now(),
+		Event: event,
+	}
+	if ts := event.Timestamp(); ts != nil {
+		entry.Time = time.UnixMilli(*ts)
+	}
+	entry.messageID, entry.toolCallID = indexedIDs(event)
+
+	switch e := event.(type) {
+	case *RunFinishedEvent, *RunErrorEvent:
+		if runID := e.RunID(); runID != "" {
+			entry.RunID = runID
+		}
+		l.runID = ""
+	}
+
+	if len(l.entries) < l.capacity {
+		l.entries = append(l.entries, entry)
+	} else {
+		l.unindex(l.entries[l.start])
+		l.entries[l.start] = entry
+		l.start = (l.start + 1) % len(l.entries)
+	}
+	l.next++
+
+	addToIndex(l.byRun, entry.RunID, entry.Seq)
+	addToIndex(l.byMessage, entry.messageID, entry.Seq)
+	addToIndex(l.byToolCall, entry.toolCallID, entry.Seq)
+
+	return entry
+}
+
+// Query returns the entries matching q, oldest first
+func (l *Log) Query(q Query) []LogEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var result []LogEntry
+	collect := func(entry LogEntry) {
+		if q.matches(entry) {
+			result = append(result, entry)
+		}
+	}
+
+	// Scan the narrowest index that applies, or the whole log
+	var seqs []uint64
+	switch {
+	case q.ToolCallID != "":
+		seqs = l.byToolCall[q.ToolCallID]
+	case q.MessageID != "":
+		seqs = l.byMessage[q.MessageID]
+	case q.RunID != "":
+		seqs = l.byRun[q.RunID]
+	default:
+		for i := range l.entries {
+			collect(l.entries[(l.start+i)%len(l.entries)])
+		}
+	}
+	for _, seq := range seqs {
+		collect(l.entryAt(seq))
+	}
+
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[len(result)-q.Limit:]
+	}
+	return result
+}
+
+// Events returns every event in the log, oldest first
+func (l *Log) Events() []Event {
+	entries := l.Query(Query{})
+	result := make([]Event, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Event
+	}
+	return result
+}
+
+// Len returns the number of events currently in the log
+func (l *Log) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// Reset removes every entry and restarts sequence numbering
+func (l *Log) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = nil
+	l.start = 0
+	l.next = 0
+	l.runID = ""
+	l.byRun = make(map[string][]uint64)
+	l.byMessage = make(map[string][]uint64)
+	l.byToolCall = make(map[string][]uint64)
+}
+
+// entryAt returns the retained entry with the given sequence number
+func (l *Log) entryAt(seq uint64) LogEntry {
+	oldest := l.next - uint64(len(l.entries))
+	return l.entries[(l.start+int(seq-oldest))%len(l.entries)]
+}
+
+// unindex removes an evicted entry, which is always the oldest one, from the
+// indexes
+func (l *Log) unindex(entry LogEntry) {
+	removeFromIndex(l.byRun, entry.RunID, entry.Seq)
+	removeFromIndex(l.byMessage, entry.messageID, entry.Seq)
+	removeFromIndex(l.byToolCall, entry.toolCallID, entry.Seq)
+}
+
+func addToIndex(index map[string][]uint64, key string, seq uint64) {
+	if key != "" {
+		index[key] = append(index[key], seq)
+	}
+}
+
+func removeFromIndex(index map[string][]uint64, key string, seq uint64) {
+	seqs := index[key]
+	if len(seqs) == 0 || seqs[0] != seq {
+		return
+	}
+	if len(seqs) == 1 {
+		delete(index, key)
+		return
+	}
+	index[key] = seqs[1:]
+}
+
+func (q Query) matches(entry LogEntry) bool {
+	if q.RunID != "" && entry.RunID != q.RunID {
+		return false
+	}
+	if q.MessageID != "" && entry.messageID != q.MessageID {
+		return false
+	}
+	if q.ToolCallID != "" && entry.toolCallID != q.ToolCallID {
+		return false
+	}
+	if len(q.Types) > 0 && !slices.Contains(q.Types, entry.Event.Type()) {
+		return false
+	}
+	if !q.Since.IsZero() && entry.Time.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+// indexedIDs returns the message and tool call IDs an event refers to
+func indexedIDs(event Event) (messageID, toolCallID string) {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	switch e := event.(type) {
+	case *TextMessageStartEvent:
+		return e.MessageID, ""
+	case *TextMessageContentEvent:
+		return e.MessageID, ""
+	case *TextMessageEndEvent:
+		return e.MessageID, ""
+	case *TextMessageChunkEvent:
+		return deref(e.MessageID), ""
+	case *ReasoningMessageStartEvent:
+		return e.MessageID, ""
+	case *ReasoningMessageContentEvent:
+		return e.MessageID, ""
+	case *ReasoningMessageEndEvent:
+		return e.MessageID, ""
+	case *ReasoningMessageChunkEvent:
+		return deref(e.MessageID), ""
+	case *ToolCallStartEvent:
+		return deref(e.ParentMessageID), e.ToolCallID
+	case *ToolCallArgsEvent:
+		return "", e.ToolCallID
+	case *ToolCallEndEvent:
+		return "", e.ToolCallID
+	case *ToolCallChunkEvent:
+		return deref(e.ParentMessageID), deref(e.ToolCallID)
+	case *ToolCallResultEvent:
+		return e.MessageID, e.ToolCallID
+	}
+	return "", ""
+}
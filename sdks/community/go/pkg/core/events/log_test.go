@@ -0,0 +1,119 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryEvents(entries []LogEntry) []Event {
+	seq := make([]Event, len(entries))
+	for i, entry := range entries {
+		seq[i] = entry.Event
+	}
+	return seq
+}
+
+func TestLog(t *testing.T) {
+	seq := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+		NewTextMessageContentEvent("msg-1", "hi"),
+		NewTextMessageEndEvent("msg-1"),
+		NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg-1")),
+		NewToolCallArgsEvent("tool-1", "{}"),
+		NewToolCallEndEvent("tool-1"),
+		NewRunFinishedEvent("thread-1", "run-1"),
+		NewRunStartedEvent("thread-1", "run-2"),
+		NewToolCallResultEvent("msg-2", "tool-1", "done"),
+		NewRunErrorEvent("boom"),
+	}
+
+	log := NewLog()
+	for _, event := range seq {
+		log.Append(event)
+	}
+	require.Equal(t, len(seq), log.Len())
+	assert.Equal(t, seq, log.Events())
+
+	t.Run("by run", func(t *testing.T) {
+		entries := log.Query(Query{RunID: "run-1"})
+		require.Len(t, entries, 8)
+		assert.Equal(t, EventTypeRunFinished, entries[7].Event.Type())
+
+		entries = log.Query(Query{RunID: "run-2"})
+		assert.Equal(t, []EventType{EventTypeRunStarted, EventTypeToolCallResult, EventTypeRunError}, eventTypes(entryEvents(entries)))
+	})
+
+	t.Run("by message and tool call", func(t *testing.T) {
+		entries := log.Query(Query{MessageID: "msg-1"})
+		assert.Equal(t, []EventType{EventTypeTextMessageStart, EventTypeTextMessageContent, EventTypeTextMessageEnd, EventTypeToolCallStart}, eventTypes(entryEvents(entries)))
+
+		entries = log.Query(Query{ToolCallID: "tool-1"})
+		assert.Equal(t, []EventType{EventTypeToolCallStart, EventTypeToolCallArgs, EventTypeToolCallEnd, EventTypeToolCallResult}, eventTypes(entryEvents(entries)))
+
+		entries = log.Query(Query{ToolCallID: "tool-1", RunID: "run-1"})
+		assert.Len(t, entries, 3)
+	})
+
+	t.Run("by type and limit", func(t *testing.T) {
+		entries := log.Query(Query{Types: []EventType{EventTypeRunStarted, EventTypeRunFinished}})
+		assert.Equal(t, []EventType{EventTypeRunStarted, EventTypeRunFinished, EventTypeRunStarted}, eventTypes(entryEvents(entries)))
+
+		entries = log.Query(Query{Types: []EventType{EventTypeRunStarted}, Limit: 1})
+		require.Len(t, entries, 1)
+		assert.Equal(t, "run-2", entries[0].RunID)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		l := NewLog()
+		l.Append(NewRunStartedEvent("thread-1", "run-1"))
+		l.Reset()
+		assert.Zero(t, l.Len())
+		assert.Empty(t, l.Query(Query{RunID: "run-1"}))
+		assert.Equal(t, uint64(0), l.Append(NewStepStartedEvent("plan")).Seq)
+	})
+}
+
+func TestLogSince(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	log := NewLog()
+	log.now = func() time.Time { return now }
+
+	early := NewStepStartedEvent("early")
+	early.SetTimestamp(now.Add(-time.Minute).UnixMilli())
+	log.Append(early)
+
+	untimed := NewStepStartedEvent("untimed")
+	untimed.TimestampMs = nil
+	log.Append(untimed)
+
+	entries := log.Query(Query{Since: now.Add(-time.Second)})
+	require.Len(t, entries, 1)
+	assert.Equal(t, "untimed", entries[0].Event.(*StepStartedEvent).StepName)
+	assert.Equal(t, now, entries[0].Time)
+}
+
+func TestLogEviction(t *testing.T) {
+	log := NewLog(WithLogCapacity(3))
+
+	log.Append(NewRunStartedEvent("thread-1", "run-1"))
+	log.Append(NewTextMessageStartEvent("msg-1"))
+	log.Append(NewTextMessageContentEvent("msg-1", "a"))
+	log.Append(NewTextMessageContentEvent("msg-1", "b"))
+	last := log.Append(NewTextMessageEndEvent("msg-1"))
+
+	assert.Equal(t, uint64(4), last.Seq)
+	assert.Equal(t, 3, log.Len())
+	assert.Equal(t, []EventType{EventTypeTextMessageContent, EventTypeTextMessageContent, EventTypeTextMessageEnd}, eventTypes(entryEvents(log.Query(Query{MessageID: "msg-1"}))))
+	assert.Len(t, log.Query(Query{RunID: "run-1"}), 3)
+	assert.Empty(t, log.Query(Query{Types: []EventType{EventTypeRunStarted}}))
+
+	for i := 0; i < 3; i++ {
+		log.Append(NewStepStartedEvent("other"))
+	}
+	assert.Empty(t, log.Query(Query{MessageID: "msg-1"}))
+	assert.Empty(t, log.byMessage)
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Merge forwards the events of every source onto a single channel, for
+// example to observe a run together with a side channel of CUSTOM events
+// from another transport. Each source is drained by its own goroutine, so a
+// busy source cannot starve the others; events of one source keep their
+// order, while events of different sources interleave as they arrive. The
+// returned channel is closed once every source is closed or ctx is done.
+func Merge(ctx context.Context, srcs ...<-chan Event) <-chan Event {
+	out := make(chan Event)
+
+	var wg sync.WaitGroup
+	wg.Add(len(srcs))
+	for _, src := range srcs {
+		go func(src <-chan Event) {
+			defer wg.Done()
+			for {
+				select {
+				case event, ok := <-src:
+					if !ok {
+						return
+					}
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(src)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
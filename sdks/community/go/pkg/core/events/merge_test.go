@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	source := func(name string, n int) <-chan Event {
+		ch := make(chan Event)
+		go func() {
+			defer close(ch)
+			for i := 0; i < n; i++ {
+				ch <- NewCustomEvent(name, WithValue(i))
+			}
+		}()
+		return ch
+	}
+
+	t.Run("forwards every event and closes after all sources", func(t *testing.T) {
+		merged := Merge(context.Background(), source("run", 100), source("analytics", 10))
+
+		next := map[string]int{}
+		for event := range merged {
+			custom := event.(*CustomEvent)
+			assert.Equal(t, next[custom.Name], custom.Value, "events of %s out of order", custom.Name)
+			next[custom.Name]++
+		}
+		assert.Equal(t, map[string]int{"run": 100, "analytics": 10}, next)
+	})
+
+	t.Run("busy sources do not starve others", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		busy := make(chan Event)
+		go func() {
+			for {
+				select {
+				case busy <- NewCustomEvent("busy"):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		merged := Merge(ctx, busy, source("quiet", 1))
+		for event := range merged {
+			if event.(*CustomEvent).Name == "quiet" {
+				return
+			}
+		}
+		t.Fatal("quiet source was never forwarded")
+	})
+
+	t.Run("closes when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		merged := Merge(ctx, make(chan Event))
+		cancel()
+
+		select {
+		case _, ok := <-merged:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("merged channel not closed after cancellation")
+		}
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		_, ok := <-Merge(context.Background())
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,183 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RepairOptions selects the repairs RepairSequence may make. The zero value
+// makes none.
+type RepairOptions struct {
+	// CloseToolCalls inserts TOOL_CALL_END for tool calls still open at
+	// RUN_FINISHED
+	CloseToolCalls bool
+	// CloseMessages inserts TEXT_MESSAGE_END and REASONING_MESSAGE_END for
+	// messages still open at RUN_FINISHED
+	CloseMessages bool
+	// CloseSteps inserts STEP_FINISHED for steps still open at RUN_FINISHED
+	CloseSteps bool
+	// CloseAtEnd also closes whatever is still open at the end of the
+	// sequence. Leave it off for sequences that may have been cut off
+	// mid-run, such as a live stream.
+	CloseAtEnd bool
+}
+
+// DefaultRepairOptions closes tool calls, messages and steps left open when
+// their run finishes
+func DefaultRepairOptions() RepairOptions {
+	return RepairOptions{
+		CloseToolCalls: true,
+		CloseMessages:  true,
+		CloseSteps:     true,
+	}
+}
+
+// Repair describes an event inserted by RepairSequence
+type Repair struct {
+	// Index is the position of the inserted event in the repaired sequence
+	Index  int
+	Event  Event
+	Reason string
+}
+
+// RepairSequence fixes common producer mistakes in a sequence instead of
+// rejecting it: end events that are missing when a run finishes are
+// inserted just before its RUN_FINISHED, tool calls first, then messages,
+// then steps from the innermost out. Events are attributed to the most
+// recently started run that has not ended, and only that run's open items
+// are closed; runs that end with RUN_ERROR are left as they are. The input
+// is not modified, and events are never removed or reordered; anything the
+// enabled options do not cover is left for validation to report. Each
+// inserted event is listed, in order, in the returned repairs.
+func RepairSequence(seq []Event, opts RepairOptions) ([]Event, []Repair) {
+	var (
+		result  []Event
+		repairs []Repair
+		runs    = map[string]*openItems{}
+		active  []string // runs started and not yet ended, oldest first
+	)
+
+	insert := func(event Event, timestamp *int64, reason string) {
+		if timestamp != nil {
+			event.SetTimestamp(*timestamp)
+		}
+		repairs = append(repairs, Repair{Index: len(result), Event: event, Reason: reason})
+		result = append(result, event)
+	}
+
+	closeOpen := func(open *openItems, timestamp *int64, at string) {
+		if open == nil {
+			return
+		}
+		if opts.CloseToolCalls {
+			for _, id := range open.toolCalls {
+				insert(NewToolCallEndEvent(id), timestamp, fmt.Sprintf("tool call %s was not ended %s", id, at))
+			}
+			open.toolCalls = nil
+		}
+		if opts.CloseMessages {
+			for _, id := range open.messages {
+				insert(NewTextMessageEndEvent(id), timestamp, fmt.Sprintf("text message %s was not ended %s", id, at))
+			}
+			for _, id := range open.reasoningMessages {
+				insert(NewReasoningMessageEndEvent(id), timestamp, fmt.Sprintf("reasoning message %s was not ended %s", id, at))
+			}
+			open.messages, open.reasoningMessages = nil, nil
+		}
+		if opts.CloseSteps {
+			for i := len(open.steps) - 1; i >= 0; i-- {
+				insert(NewStepFinishedEvent(open.steps[i]), timestamp, fmt.Sprintf("step %s was not finished %s", open.steps[i], at))
+			}
+			open.steps = nil
+		}
+	}
+
+	// currentRun returns the ID of the run new events belong to; events
+	// outside any run are tracked under the empty ID
+	currentRun := func() string {
+		if len(active) == 0 {
+			return ""
+		}
+		return active[len(active)-1]
+	}
+	current := func() *openItems {
+		runID := currentRun()
+		if runs[runID] == nil {
+			runs[runID] = &openItems{}
+		}
+		return runs[runID]
+	}
+	// endRun stops tracking a run and returns what it left open. A run
+	// that was never started claims the items tracked outside any run.
+	endRun := func(runID string) *openItems {
+		if _, ok := runs[runID]; !ok && !slices.Contains(active, runID) {
+			runID = ""
+		}
+		open := runs[runID]
+		delete(runs, runID)
+		active = slices.DeleteFunc(active, func(id string) bool { return id == runID })
+		return open
+	}
+
+	for _, event := range seq {
+		switch e := event.(type) {
+		case *RunStartedEvent:
+			active = append(active, e.RunID())
+		case *ToolCallStartEvent:
+			open := current()
+			open.toolCalls = append(open.toolCalls, e.ToolCallID)
+		case *ToolCallEndEvent:
+			open := current()
+			open.toolCalls = slices.DeleteFunc(open.toolCalls, func(id string) bool { return id == e.ToolCallID })
+		case *TextMessageStartEvent:
+			open := current()
+			open.messages = append(open.messages, e.MessageID)
+		case *TextMessageEndEvent:
+			open := current()
+			open.messages = slices.DeleteFunc(open.messages, func(id string) bool { return id == e.MessageID })
+		case *ReasoningMessageStartEvent:
+			open := current()
+			open.reasoningMessages = append(open.reasoningMessages, e.MessageID)
+		case *ReasoningMessageEndEvent:
+			open := current()
+			open.reasoningMessages = slices.DeleteFunc(open.reasoningMessages, func(id string) bool { return id == e.MessageID })
+		case *StepStartedEvent:
+			open := current()
+			open.steps = append(open.steps, e.StepName)
+		case *StepFinishedEvent:
+			open := current()
+			open.steps = slices.DeleteFunc(open.steps, func(name string) bool { return name == e.StepName })
+		case *RunFinishedEvent:
+			closeOpen(endRun(e.RunID()), e.Timestamp(), "before RUN_FINISHED")
+		case *RunErrorEvent:
+			// An errored run may end with anything open; nothing of it may
+			// follow its RUN_ERROR, so forget it instead of repairing it
+			runID := e.RunID()
+			if runID == "" {
+				runID = currentRun()
+			}
+			endRun(runID)
+		}
+		result = append(result, event)
+	}
+
+	if opts.CloseAtEnd {
+		var timestamp *int64
+		if len(seq) > 0 && seq[len(seq)-1] != nil {
+			timestamp = seq[len(seq)-1].Timestamp()
+		}
+		for _, runID := range append([]string{""}, active...) {
+			closeOpen(runs[runID], timestamp, "by the end of the sequence")
+		}
+	}
+
+	return result, repairs
+}
+
+// openItems holds the tool calls, messages and steps a run has left open
+type openItems struct {
+	toolCalls         []string
+	messages          []string
+	reasoningMessages []string
+	steps             []string
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypes(seq []Event) []EventType {
+	types := make([]EventType, len(seq))
+	for i, event := range seq {
+		types[i] = event.Type()
+	}
+	return types
+}
+
+func TestRepairSequence(t *testing.T) {
+	t.Run("missing text message end", func(t *testing.T) {
+		finished := NewRunFinishedEvent("thread-1", "run-1")
+		finished.SetTimestamp(1700000000000)
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+			NewTextMessageContentEvent("msg-1", "hello"),
+			finished,
+		}
+
+		repaired, repairs := RepairSequence(seq, DefaultRepairOptions())
+		assert.Equal(t, []EventType{
+			EventTypeRunStarted, EventTypeTextMessageStart, EventTypeTextMessageContent,
+			EventTypeTextMessageEnd, EventTypeRunFinished,
+		}, eventTypes(repaired))
+		require.Len(t, repairs, 1)
+		assert.Equal(t, 3, repairs[0].Index)
+		assert.Same(t, repaired[3], repairs[0].Event)
+		assert.Equal(t, "text message msg-1 was not ended before RUN_FINISHED", repairs[0].Reason)
+		assert.Equal(t, int64(1700000000000), *repairs[0].Event.Timestamp())
+		assert.NoError(t, ValidateSequence(repaired))
+		assert.Len(t, seq, 4, "input is not modified")
+	})
+
+	t.Run("dangling tool call and step", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewStepStartedEvent("outer"),
+			NewStepStartedEvent("inner"),
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+			NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg-1")),
+			NewToolCallArgsEvent("tool-1", `{"q":"go"}`),
+			NewStepFinishedEvent("inner"),
+			NewRunFinishedEvent("thread-1", "run-1"),
+		}
+
+		repaired, repairs := RepairSequence(seq, DefaultRepairOptions())
+		assert.Equal(t, []EventType{EventTypeToolCallEnd, EventTypeTextMessageEnd, EventTypeStepFinished, EventTypeRunFinished}, eventTypes(repaired[7:]))
+		require.Len(t, repairs, 3)
+		assert.Equal(t, "tool call tool-1 was not ended before RUN_FINISHED", repairs[0].Reason)
+		assert.Equal(t, "step outer was not finished before RUN_FINISHED", repairs[2].Reason)
+		assert.NoError(t, ValidateSequence(repaired))
+	})
+
+	t.Run("reasoning messages", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewReasoningMessageStartEvent("reason-1", "reasoning"),
+			NewRunFinishedEvent("thread-1", "run-1"),
+		}
+
+		repaired, repairs := RepairSequence(seq, RepairOptions{CloseMessages: true})
+		require.Len(t, repairs, 1)
+		assert.Equal(t, EventTypeReasoningMessageEnd, repaired[2].Type())
+	})
+
+	t.Run("well formed sequences are unchanged", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{}`)
+		repaired, repairs := RepairSequence(seq, DefaultRepairOptions())
+		assert.Equal(t, seq, repaired)
+		assert.Empty(t, repairs)
+	})
+
+	t.Run("disabled repairs", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewToolCallStartEvent("tool-1", "search"),
+			NewRunFinishedEvent("thread-1", "run-1"),
+		}
+		repaired, repairs := RepairSequence(seq, RepairOptions{CloseMessages: true})
+		assert.Equal(t, seq, repaired)
+		assert.Empty(t, repairs)
+	})
+
+	t.Run("runs ending in RUN_ERROR are left alone", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewToolCallStartEvent("tool-1", "search"),
+			NewToolCallArgsEvent("tool-1", `{"q":`),
+			NewRunErrorEvent("model overloaded", WithRunID("run-1")),
+		}
+
+		opts := DefaultRepairOptions()
+		opts.CloseAtEnd = true
+		repaired, repairs := RepairSequence(seq, opts)
+		assert.Equal(t, seq, repaired)
+		assert.Empty(t, repairs)
+	})
+
+	t.Run("RUN_ERROR only forgets its own run", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+			NewRunStartedEvent("thread-1", "run-2"),
+			NewToolCallStartEvent("tool-2", "search"),
+			NewRunErrorEvent("model overloaded", WithRunID("run-2")),
+			NewRunFinishedEvent("thread-1", "run-1"),
+		}
+
+		repaired, repairs := RepairSequence(seq, DefaultRepairOptions())
+		require.Len(t, repairs, 1)
+		assert.Equal(t, "text message msg-1 was not ended before RUN_FINISHED", repairs[0].Reason)
+		assert.Equal(t, []EventType{EventTypeRunError, EventTypeTextMessageEnd, EventTypeRunFinished}, eventTypes(repaired[4:]))
+	})
+
+	t.Run("truncated sequences", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+		}
+
+		_, repairs := RepairSequence(seq, DefaultRepairOptions())
+		assert.Empty(t, repairs, "open messages are left alone unless CloseAtEnd is set")
+
+		opts := DefaultRepairOptions()
+		opts.CloseAtEnd = true
+		repaired, repairs := RepairSequence(seq, opts)
+		require.Len(t, repairs, 1)
+		assert.Equal(t, "text message msg-1 was not ended by the end of the sequence", repairs[0].Reason)
+		assert.Equal(t, EventTypeTextMessageEnd, repaired[2].Type())
+	})
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationReportEventName is the CUSTOM event name used for validation
+// reports, prefixed with ag-ui because debugging tools commonly define
+// their own validation_report events
+const ValidationReportEventName = "ag-ui.validation_report"
+
+// ReportIssue is a single finding in an ErrorReport
+type ReportIssue struct {
+	// Sequence is the index of the sequence the issue was found in
+	Sequence int `json:"sequence"`
+	// Event is the index of the offending event within its sequence
+	Event   int    `json:"event"`
+	Message string `json:"message"`
+}
+
+// ErrorReport collects the validation findings of one or more event
+// sequences. It serializes to JSON for logging and tooling.
+type ErrorReport struct {
+	Errors   []ReportIssue `json:"errors,omitempty"`
+	Warnings []ReportIssue `json:"warnings,omitempty"`
+}
+
+// HasErrors reports whether the report contains any errors
+func (r *ErrorReport) HasErrors() bool {
+	return r != nil && len(r.Errors) > 0
+}
+
+// String renders the report with one issue per line
+func (r *ErrorReport) String() string {
+	if r == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, issue := range r.Errors {
+		fmt.Fprintf(&sb, "error: sequence %d, event %d: %s\n", issue.Sequence, issue.Event, issue.Message)
+	}
+	for _, issue := range r.Warnings {
+		fmt.Fprintf(&sb, "warning: sequence %d, event %d: %s\n", issue.Sequence, issue.Event, issue.Message)
+	}
+	return sb.String()
+}
+
+// NewValidationReportEvent wraps a validation report in a CUSTOM event, so a
+// server can surface failed validation to debugging UIs. A nil report is sent
+// as an empty one; ValidationReportFromEvent recovers the report on the
+// receiving side.
+func NewValidationReportEvent(report *ErrorReport) *CustomEvent {
+	var value ErrorReport
+	if report != nil {
+		value = *report
+	}
+	return NewTypedCustomEvent(ValidationReportEventName, value)
+}
+
+// ValidationReportFromEvent returns the report carried by a validation
+// report event. It reports false for any other event.
+func ValidationReportFromEvent(event Event) (*ErrorReport, bool) {
+	custom, ok := event.(*CustomEvent)
+	if !ok || custom.Name != ValidationReportEventName {
+		return nil, false
+	}
+
+	report, err := CustomEventValue[ErrorReport](custom)
+	if err != nil {
+		return nil, false
+	}
+	return &report, true
+}
@@ -234,6 +234,16 @@ type RunErrorEvent struct {
 	Code       *string `json:"code,omitempty"`
 	Message    string  `json:"message"`
 	RunIDValue string  `json:"runId,omitempty"`
+	// Causes lists the errors that led to this one, outermost first, so
+	// frontends can show the full error trace. Code and Message remain the
+	// summary for consumers that ignore them.
+	Causes []RunErrorCause `json:"causes,omitempty"`
+}
+
+// RunErrorCause is one error in the cause chain of a RunErrorEvent
+type RunErrorCause struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
 }
 
 // NewRunErrorEvent creates a new run error event
@@ -267,6 +277,14 @@ func WithRunID(runID string) RunErrorOption {
 	}
 }
 
+// WithErrorCause appends an error to the cause chain. Apply it once per
+// cause, outermost first.
+func WithErrorCause(code, message string) RunErrorOption {
+	return func(e *RunErrorEvent) {
+		e.Causes = append(e.Causes, RunErrorCause{Code: code, Message: message})
+	}
+}
+
 // WithAutoRunIDError automatically generates a unique run ID if the provided runID is empty
 func WithAutoRunIDError() RunErrorOption {
 	return func(e *RunErrorEvent) {
@@ -286,6 +304,12 @@ func (e *RunErrorEvent) Validate() error {
 		return fmt.Errorf("RunErrorEvent validation failed: message field is required")
 	}
 
+	for i, cause := range e.Causes {
+		if cause.Message == "" {
+			return fmt.Errorf("RunErrorEvent validation failed: message field is required for cause %d", i)
+		}
+	}
+
 	return nil
 }
 
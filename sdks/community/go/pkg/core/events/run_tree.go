@@ -0,0 +1,249 @@
+package events
+
+// CorrelateToolCalls maps each parent message ID to the IDs of the tool calls
+// started under it, in stream order. Tool calls without a ParentMessageID are
+// not included.
+func CorrelateToolCalls(seq []Event) map[string][]string {
+	result := make(map[string][]string)
+	seen := make(map[string]bool)
+
+	add := func(parentMessageID *string, toolCallID string) {
+		if parentMessageID == nil || *parentMessageID == "" || toolCallID == "" || seen[toolCallID] {
+			return
+		}
+		seen[toolCallID] = true
+		result[*parentMessageID] = append(result[*parentMessageID], toolCallID)
+	}
+
+	for _, event := range seq {
+		switch e := event.(type) {
+		case *ToolCallStartEvent:
+			add(e.ParentMessageID, e.ToolCallID)
+		case *ToolCallChunkEvent:
+			if e.ToolCallID != nil {
+				add(e.ParentMessageID, *e.ToolCallID)
+			}
+		}
+	}
+
+	return result
+}
+
+// RunTree is a hierarchical view of an event stream: runs contain steps,
+// steps contain messages, and messages contain the tool calls they issued
+type RunTree struct {
+	Runs []*RunNode `json:"runs"`
+}
+
+// RunNode is a single run in a RunTree. Messages and tool calls emitted
+// outside of any step are attached to the run directly.
+type RunNode struct {
+	ThreadID string `json:"threadId,omitempty"`
+	RunID    string `json:"runId,omitempty"`
+	Finished bool   `json:"finished"`
+	Error    string `json:"error,omitempty"`
+
+	Steps     []*StepNode     `json:"steps,omitempty"`
+	Messages  []*MessageNode  `json:"messages,omitempty"`
+	ToolCalls []*ToolCallNode `json:"toolCalls,omitempty"`
+}
+
+// StepNode is a step within a run
+type StepNode struct {
+	Name     string `json:"name"`
+	Finished bool   `json:"finished"`
+
+	Messages  []*MessageNode  `json:"messages,omitempty"`
+	ToolCalls []*ToolCallNode `json:"toolCalls,omitempty"`
+}
+
+// MessageNode is a text message together with the tool calls that name it
+// as their parent. A message node is also created for a parent message ID
+// that never appeared as a text message, since assistants often issue tool
+// calls without any text.
+type MessageNode struct {
+	MessageID string `json:"messageId"`
+	Role      string `json:"role,omitempty"`
+	Content   string `json:"content,omitempty"`
+
+	ToolCalls []*ToolCallNode `json:"toolCalls,omitempty"`
+}
+
+// ToolCallNode is a tool call with its accumulated arguments and, once
+// received, its result
+type ToolCallNode struct {
+	ToolCallID   string  `json:"toolCallId"`
+	ToolCallName string  `json:"toolCallName,omitempty"`
+	Args         string  `json:"args,omitempty"`
+	Finished     bool    `json:"finished"`
+	Result       *string `json:"result,omitempty"`
+}
+
+// treeContainer is the step or run that newly seen messages and tool calls
+// are attached to
+type treeContainer struct {
+	messages  *[]*MessageNode
+	toolCalls *[]*ToolCallNode
+}
+
+// runTreeBuilder holds the lookup state used while building a RunTree
+type runTreeBuilder struct {
+	tree      *RunTree
+	run       *RunNode
+	steps     []*StepNode // open steps of the current run, innermost last
+	messages  map[string]*MessageNode
+	toolCalls map[string]*ToolCallNode
+
+	lastChunkMessage  *MessageNode
+	lastChunkToolCall *ToolCallNode
+}
+
+// BuildRunTree arranges a flat event stream into runs, steps, messages and
+// tool calls that a UI can render directly. Events that appear before any
+// RUN_STARTED are attached to a run with empty IDs. Events that do not
+// affect the structure, such as state or activity events, are ignored.
+func BuildRunTree(seq []Event) *RunTree {
+	b := &runTreeBuilder{
+		tree:      &RunTree{},
+		messages:  make(map[string]*MessageNode),
+		toolCalls: make(map[string]*ToolCallNode),
+	}
+
+	for _, event := range seq {
+		b.add(event)
+	}
+
+	return b.tree
+}
+
+func (b *runTreeBuilder) add(event Event) {
+	switch e := event.(type) {
+	case *RunStartedEvent:
+		b.run = &RunNode{ThreadID: e.ThreadID(), RunID: e.RunID()}
+		b.tree.Runs = append(b.tree.Runs, b.run)
+		b.steps = nil
+	case *RunFinishedEvent:
+		b.currentRun().Finished = true
+		b.steps = nil
+	case *RunErrorEvent:
+		b.currentRun().Error = e.Message
+		b.steps = nil
+	case *StepStartedEvent:
+		step := &StepNode{Name: e.StepName}
+		run := b.currentRun()
+		run.Steps = append(run.Steps, step)
+		b.steps = append(b.steps, step)
+	case *StepFinishedEvent:
+		for i := len(b.steps) - 1; i >= 0; i-- {
+			if b.steps[i].Name == e.StepName {
+				b.steps[i].Finished = true
+				b.steps = append(b.steps[:i], b.steps[i+1:]...)
+				break
+			}
+		}
+	case *TextMessageStartEvent:
+		message := b.message(e.MessageID)
+		if e.Role != nil {
+			message.Role = *e.Role
+		}
+	case *TextMessageContentEvent:
+		b.message(e.MessageID).Content += e.Delta
+	case *TextMessageChunkEvent:
+		message := b.lastChunkMessage
+		if e.MessageID != nil {
+			message = b.message(*e.MessageID)
+		}
+		if message == nil {
+			return
+		}
+		b.lastChunkMessage = message
+		if e.Role != nil {
+			message.Role = *e.Role
+		}
+		if e.Delta != nil {
+			message.Content += *e.Delta
+		}
+	case *ToolCallStartEvent:
+		toolCall := b.toolCall(e.ToolCallID, e.ParentMessageID)
+		toolCall.ToolCallName = e.ToolCallName
+	case *ToolCallArgsEvent:
+		b.toolCall(e.ToolCallID, nil).Args += e.Delta
+	case *ToolCallEndEvent:
+		b.toolCall(e.ToolCallID, nil).Finished = true
+	case *ToolCallChunkEvent:
+		toolCall := b.lastChunkToolCall
+		if e.ToolCallID != nil {
+			toolCall = b.toolCall(*e.ToolCallID, e.ParentMessageID)
+		}
+		if toolCall == nil {
+			return
+		}
+		b.lastChunkToolCall = toolCall
+		if e.ToolCallName != nil {
+			toolCall.ToolCallName = *e.ToolCallName
+		}
+		if e.Delta != nil {
+			toolCall.Args += *e.Delta
+		}
+	case *ToolCallResultEvent:
+		toolCall := b.toolCall(e.ToolCallID, nil)
+		content := e.Content
+		toolCall.Result = &content
+		toolCall.Finished = true
+	}
+}
+
+// currentRun returns the run being built, creating an anonymous one for
+// events that precede RUN_STARTED
+func (b *runTreeBuilder) currentRun() *RunNode {
+	if b.run == nil {
+		b.run = &RunNode{}
+		b.tree.Runs = append(b.tree.Runs, b.run)
+	}
+	return b.run
+}
+
+func (b *runTreeBuilder) container() treeContainer {
+	if len(b.steps) > 0 {
+		step := b.steps[len(b.steps)-1]
+		return treeContainer{messages: &step.Messages, toolCalls: &step.ToolCalls}
+	}
+	run := b.currentRun()
+	return treeContainer{messages: &run.Messages, toolCalls: &run.ToolCalls}
+}
+
+// message returns the node for messageID, attaching a new one to the current
+// container if it has not been seen yet
+func (b *runTreeBuilder) message(messageID string) *MessageNode {
+	if message, ok := b.messages[messageID]; ok {
+		return message
+	}
+
+	message := &MessageNode{MessageID: messageID}
+	b.messages[messageID] = message
+	c := b.container()
+	*c.messages = append(*c.messages, message)
+	return message
+}
+
+// toolCall returns the node for toolCallID, attaching a new one under its
+// parent message, or the current container if it has none, when it has not
+// been seen yet
+func (b *runTreeBuilder) toolCall(toolCallID string, parentMessageID *string) *ToolCallNode {
+	if toolCall, ok := b.toolCalls[toolCallID]; ok {
+		return toolCall
+	}
+
+	toolCall := &ToolCallNode{ToolCallID: toolCallID}
+	b.toolCalls[toolCallID] = toolCall
+
+	if parentMessageID != nil && *parentMessageID != "" {
+		parent := b.message(*parentMessageID)
+		parent.ToolCalls = append(parent.ToolCalls, toolCall)
+	} else {
+		c := b.container()
+		*c.toolCalls = append(*c.toolCalls, toolCall)
+	}
+
+	return toolCall
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelateToolCalls(t *testing.T) {
+	seq := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+		NewTextMessageEndEvent("msg-1"),
+		NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg-1")),
+		NewToolCallEndEvent("tool-1"),
+		NewToolCallStartEvent("tool-2", "fetch", WithParentMessageID("msg-1")),
+		NewToolCallEndEvent("tool-2"),
+		NewToolCallChunkEvent().WithToolCallChunkID("tool-3").WithToolCallChunkParentMessageID("msg-2"),
+		NewToolCallChunkEvent().WithToolCallChunkID("tool-3").WithToolCallChunkParentMessageID("msg-2").WithToolCallChunkDelta("{}"),
+		NewToolCallStartEvent("tool-4", "orphan"),
+		NewRunFinishedEvent("thread-1", "run-1"),
+	}
+
+	assert.Equal(t, map[string][]string{
+		"msg-1": {"tool-1", "tool-2"},
+		"msg-2": {"tool-3"},
+	}, CorrelateToolCalls(seq))
+
+	assert.Empty(t, CorrelateToolCalls(nil))
+}
+
+func TestBuildRunTree(t *testing.T) {
+	t.Run("nests steps, messages and tool calls", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewStepStartedEvent("plan"),
+			NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+			NewTextMessageContentEvent("msg-1", "Looking "),
+			NewTextMessageContentEvent("msg-1", "it up"),
+			NewTextMessageEndEvent("msg-1"),
+			NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg-1")),
+			NewToolCallArgsEvent("tool-1", `{"q":`),
+			NewToolCallArgsEvent("tool-1", `"go"}`),
+			NewToolCallEndEvent("tool-1"),
+			NewToolCallResultEvent("msg-2", "tool-1", "found"),
+			NewStepFinishedEvent("plan"),
+			NewTextMessageStartEvent("msg-3", WithRole("assistant")),
+			NewTextMessageContentEvent("msg-3", "done"),
+			NewTextMessageEndEvent("msg-3"),
+			NewRunFinishedEvent("thread-1", "run-1"),
+		}
+
+		tree := BuildRunTree(seq)
+		require.Len(t, tree.Runs, 1)
+
+		run := tree.Runs[0]
+		assert.Equal(t, "thread-1", run.ThreadID)
+		assert.Equal(t, "run-1", run.RunID)
+		assert.True(t, run.Finished)
+
+		require.Len(t, run.Steps, 1)
+		step := run.Steps[0]
+		assert.Equal(t, "plan", step.Name)
+		assert.True(t, step.Finished)
+
+		require.Len(t, step.Messages, 1)
+		message := step.Messages[0]
+		assert.Equal(t, "msg-1", message.MessageID)
+		assert.Equal(t, "assistant", message.Role)
+		assert.Equal(t, "Looking it up", message.Content)
+
+		require.Len(t, message.ToolCalls, 1)
+		toolCall := message.ToolCalls[0]
+		assert.Equal(t, "search", toolCall.ToolCallName)
+		assert.Equal(t, `{"q":"go"}`, toolCall.Args)
+		assert.True(t, toolCall.Finished)
+		require.NotNil(t, toolCall.Result)
+		assert.Equal(t, "found", *toolCall.Result)
+
+		require.Len(t, run.Messages, 1)
+		assert.Equal(t, "msg-3", run.Messages[0].MessageID)
+	})
+
+	t.Run("creates a message node for a parent without text", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg-1")),
+			NewToolCallStartEvent("tool-2", "fetch"),
+		}
+
+		run := BuildRunTree(seq).Runs[0]
+		require.Len(t, run.Messages, 1)
+		assert.Equal(t, "msg-1", run.Messages[0].MessageID)
+		require.Len(t, run.Messages[0].ToolCalls, 1)
+		assert.Equal(t, "tool-1", run.Messages[0].ToolCalls[0].ToolCallID)
+
+		require.Len(t, run.ToolCalls, 1)
+		assert.Equal(t, "tool-2", run.ToolCalls[0].ToolCallID)
+		assert.False(t, run.ToolCalls[0].Finished)
+	})
+
+	t.Run("follows chunk events", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID("msg-1").WithChunkRole("assistant").WithChunkDelta("Hi"),
+			NewTextMessageChunkEvent(nil, nil, nil).WithChunkDelta(" there"),
+			NewToolCallChunkEvent().WithToolCallChunkID("tool-1").WithToolCallChunkName("search").WithToolCallChunkParentMessageID("msg-1").WithToolCallChunkDelta(`{"q":`),
+			NewToolCallChunkEvent().WithToolCallChunkDelta(`1}`),
+		}
+
+		run := BuildRunTree(seq).Runs[0]
+		require.Len(t, run.Messages, 1)
+		assert.Equal(t, "Hi there", run.Messages[0].Content)
+		require.Len(t, run.Messages[0].ToolCalls, 1)
+		assert.Equal(t, "search", run.Messages[0].ToolCalls[0].ToolCallName)
+		assert.Equal(t, `{"q":1}`, run.Messages[0].ToolCalls[0].Args)
+	})
+
+	t.Run("separates runs and records errors", func(t *testing.T) {
+		seq := []Event{
+			NewTextMessageStartEvent("msg-0"),
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewRunErrorEvent("boom", WithRunID("run-1")),
+			NewRunStartedEvent("thread-1", "run-2"),
+			NewStepStartedEvent("work"),
+		}
+
+		tree := BuildRunTree(seq)
+		require.Len(t, tree.Runs, 3)
+		assert.Empty(t, tree.Runs[0].RunID)
+		assert.Equal(t, "msg-0", tree.Runs[0].Messages[0].MessageID)
+		assert.Equal(t, "boom", tree.Runs[1].Error)
+		assert.False(t, tree.Runs[1].Finished)
+		require.Len(t, tree.Runs[2].Steps, 1)
+		assert.False(t, tree.Runs[2].Steps[0].Finished)
+	})
+}
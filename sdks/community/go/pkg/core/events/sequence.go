@@ -0,0 +1,67 @@
+package events
+
+import "sync"
+
+// WithSequence sets the sequence number of event and returns the event.
+// Producers number the events of a run from one upwards so that consumers
+// can recognize events they have already processed, for example when a
+// run's history is replayed after a reconnect.
+func WithSequence[E Event](n uint64, event E) E {
+	if base := event.GetBaseEvent(); base != nil {
+		base.Sequence = &n
+	}
+	return event
+}
+
+// SequenceGuard drops replayed events at ingest. It remembers the highest
+// sequence number accepted for each run and rejects events of that run whose
+// sequence number is not higher, which makes processing exactly-once for
+// numbered events regardless of transport retries. Events without a sequence
+// number are always accepted. A SequenceGuard is safe for concurrent use.
+type SequenceGuard struct {
+	mu      sync.Mutex
+	highest map[string]uint64
+}
+
+// NewSequenceGuard creates an empty sequence guard
+func NewSequenceGuard() *SequenceGuard {
+	return &SequenceGuard{highest: make(map[string]uint64)}
+}
+
+// Accept reports whether event should be processed as part of the given
+// run, and records its sequence number if so. Callers pass the run the
+// event was received for, since most events do not carry a run ID.
+func (g *SequenceGuard) Accept(runID string, event Event) bool {
+	base := event.GetBaseEvent()
+	if base == nil || base.Sequence == nil {
+		return true
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if highest, ok := g.highest[runID]; ok && *base.Sequence <= highest {
+		return false
+	}
+	g.highest[runID] = *base.Sequence
+	return true
+}
+
+// Highest returns the highest sequence number accepted for a run, and false
+// if none has been
+func (g *SequenceGuard) Highest(runID string) (uint64, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	highest, ok := g.highest[runID]
+	return highest, ok
+}
+
+// Forget discards what the guard knows about a run. Call it once a run's
+// events can no longer be replayed to bound the guard's memory.
+func (g *SequenceGuard) Forget(runID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.highest, runID)
+}
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSequence(t *testing.T) {
+	event := WithSequence(3, NewTextMessageContentEvent("msg-1", "hi"))
+	require.NotNil(t, event.Sequence)
+	assert.Equal(t, uint64(3), *event.Sequence)
+
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"sequence":3`)
+
+	decoded, err := EventFromJSON(data)
+	require.NoError(t, err)
+	require.NotNil(t, decoded.GetBaseEvent().Sequence)
+	assert.Equal(t, uint64(3), *decoded.GetBaseEvent().Sequence)
+
+	plain, err := NewTextMessageContentEvent("msg-1", "hi").ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "sequence")
+}
+
+func TestSequenceGuard(t *testing.T) {
+	guard := NewSequenceGuard()
+
+	assert.True(t, guard.Accept("run-1", WithSequence(1, NewRunStartedEvent("thread-1", "run-1"))))
+	assert.True(t, guard.Accept("run-1", WithSequence(2, NewStepStartedEvent("plan"))))
+	assert.True(t, guard.Accept("run-1", WithSequence(5, NewStepFinishedEvent("plan"))), "gaps are allowed")
+
+	// Replayed events are dropped
+	assert.False(t, guard.Accept("run-1", WithSequence(2, NewStepStartedEvent("plan"))))
+	assert.False(t, guard.Accept("run-1", WithSequence(5, NewStepFinishedEvent("plan"))))
+
+	// Runs are tracked independently and unnumbered events always pass
+	assert.True(t, guard.Accept("run-2", WithSequence(1, NewRunStartedEvent("thread-1", "run-2"))))
+	assert.True(t, guard.Accept("run-1", NewStepStartedEvent("plan")))
+	assert.True(t, guard.Accept("run-1", NewStepStartedEvent("plan")))
+
+	highest, ok := guard.Highest("run-1")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), highest)
+
+	guard.Forget("run-1")
+	_, ok = guard.Highest("run-1")
+	assert.False(t, ok)
+	assert.True(t, guard.Accept("run-1", WithSequence(1, NewRunStartedEvent("thread-1", "run-1"))))
+}
@@ -26,7 +26,9 @@ type ToolCall = coretypes.ToolCall
 
 type Function = coretypes.FunctionCall
 
-// StateSnapshotEvent contains a complete snapshot of the state
+// StateSnapshotEvent contains a complete snapshot of the state. A snapshot
+// containing NaN or infinite floats fails to serialize with a
+// NonFiniteNumberError.
 type StateSnapshotEvent struct {
 	*BaseEvent
 	Snapshot any `json:"snapshot"`
@@ -58,6 +60,17 @@ func (e *StateSnapshotEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// MarshalJSON rejects snapshots containing NaN or infinite floats, so that
+// every codec reports the same NonFiniteNumberError
+func (e *StateSnapshotEvent) MarshalJSON() ([]byte, error) {
+	if err := checkFinite(EventTypeStateSnapshot, "/snapshot", e.Snapshot); err != nil {
+		return nil, err
+	}
+
+	type stateSnapshotEvent StateSnapshotEvent
+	return json.Marshal((*stateSnapshotEvent)(e))
+}
+
 // JSONPatchOperation represents a JSON Patch operation (RFC 6902)
 type JSONPatchOperation struct {
 	Op    string `json:"op"`              // "add", "remove", "replace", "move", "copy", "test"
@@ -121,6 +134,9 @@ func validateJSONPatchOperation(op JSONPatchOperation) error {
 	if (op.Op == "move" || op.Op == "copy") && op.From == "" {
 		return fmt.Errorf("from field is required for %s operation", op.Op)
 	}
+	if op.Op != "move" && op.Op != "copy" && op.From != "" {
+		return fmt.Errorf("from field is only valid for move and copy operations, got: %s", op.Op)
+	}
 
 	return nil
 }
@@ -150,11 +166,24 @@ func (e *MessagesSnapshotEvent) Validate() error {
 		return err
 	}
 
-	// Validate each message
+	// Validate each message. Tool messages must answer a tool call issued
+	// by an earlier assistant message in the same snapshot.
+	toolCallIDs := make(map[string]bool)
 	for i, msg := range e.Messages {
 		if err := validateMessage(msg); err != nil {
 			return fmt.Errorf("invalid message at index %d: %w", i, err)
 		}
+
+		switch msg.Role {
+		case coretypes.RoleAssistant:
+			for _, toolCall := range msg.ToolCalls {
+				toolCallIDs[toolCall.ID] = true
+			}
+		case coretypes.RoleTool:
+			if !toolCallIDs[msg.ToolCallID] {
+				return fmt.Errorf("invalid message at index %d: tool message %s references tool call %s that no preceding assistant message made", i, msg.ID, msg.ToolCallID)
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"errors"
+	"sync"
+)
+
+// StepErrorEventName is the CUSTOM event name StepScope emits before
+// STEP_FINISHED when a step ends with an error. The ag-ui prefix keeps it
+// apart from step_error events an application may define itself.
+const StepErrorEventName = "ag-ui.step_error"
+
+// StepError is the value of a step error event
+type StepError struct {
+	StepName string `json:"stepName"`
+	Message  string `json:"message"`
+}
+
+// StepScope emits STEP_STARTED for name and returns a function that emits the
+// matching STEP_FINISHED, so steps stay paired even on early returns. Call
+// done from a deferred closure over a named result, so it sees the error the
+// function returns rather than the value err had when the defer statement
+// ran:
+//
+//	func plan() (err error) {
+//		done := events.StepScope(emit, "plan")
+//		defer func() { err = done(err) }()
+//		...
+//	}
+//
+// If done is given an error, a CUSTOM step error event describing it is
+// emitted before STEP_FINISHED. Only the first call to done emits anything.
+// done returns the error it was given, joined with the first error reported
+// by emit, so assigning its result back to err keeps the step's error and
+// surfaces emit failures. If STEP_STARTED could not be emitted, STEP_FINISHED
+// is not emitted either.
+func StepScope(emit func(Event) error, name string) (done func(error) error) {
+	startErr := emit(NewStepStartedEvent(name))
+
+	var once sync.Once
+	var doneErr error
+
+	return func(stepErr error) error {
+		once.Do(func() {
+			if startErr != nil {
+				doneErr = startErr
+				return
+			}
+
+			if stepErr != nil {
+				marker := NewTypedCustomEvent(StepErrorEventName, StepError{StepName: name, Message: stepErr.Error()})
+				if err := emit(marker); err != nil {
+					doneErr = err
+				}
+			}
+
+			if err := emit(NewStepFinishedEvent(name)); err != nil && doneErr == nil {
+				doneErr = err
+			}
+		})
+
+		switch {
+		case stepErr == nil:
+			return doneErr
+		case doneErr == nil:
+			return stepErr
+		}
+		return errors.Join(stepErr, doneErr)
+	}
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepScope(t *testing.T) {
+	var emitted []Event
+	emit := func(event Event) error {
+		emitted = append(emitted, event)
+		return nil
+	}
+
+	t.Run("pairs started and finished", func(t *testing.T) {
+		emitted = nil
+		run := func() (err error) {
+			done := StepScope(emit, "plan")
+			defer func() { err = done(err) }()
+			return nil
+		}
+		require.NoError(t, run())
+
+		require.Len(t, emitted, 2)
+		assert.Equal(t, "plan", emitted[0].(*StepStartedEvent).StepName)
+		assert.Equal(t, "plan", emitted[1].(*StepFinishedEvent).StepName)
+	})
+
+	t.Run("marks failed steps", func(t *testing.T) {
+		emitted = nil
+		done := StepScope(emit, "search")
+		stepErr := errors.New("no results")
+		assert.Equal(t, stepErr, done(stepErr))
+		require.NoError(t, done(nil))
+
+		require.Len(t, emitted, 3)
+		marker := emitted[1].(*CustomEvent)
+		assert.Equal(t, StepErrorEventName, marker.Name)
+		assert.Equal(t, StepError{StepName: "search", Message: "no results"}, marker.Value)
+		assert.Equal(t, EventTypeStepFinished, emitted[2].Type())
+
+		seq := append([]Event{NewRunStartedEvent("thread-1", "run-1")}, emitted...)
+		assert.NoError(t, ValidateSequence(seq))
+	})
+
+	t.Run("reports emit failures", func(t *testing.T) {
+		failure := errors.New("connection closed")
+
+		calls := 0
+		done := StepScope(func(Event) error { calls++; return failure }, "plan")
+		assert.ErrorIs(t, done(nil), failure)
+		assert.Equal(t, 1, calls, "STEP_FINISHED must not be emitted when STEP_STARTED failed")
+
+		emitted = nil
+		done = StepScope(func(event Event) error {
+			if event.Type() == EventTypeStepFinished {
+				return failure
+			}
+			return emit(event)
+		}, "plan")
+		assert.ErrorIs(t, done(nil), failure)
+	})
+
+	t.Run("deferred done sees the returned error", func(t *testing.T) {
+		emitted = nil
+		stepErr := errors.New("no results")
+		run := func() (err error) {
+			done := StepScope(emit, "search")
+			defer func() { err = done(err) }()
+			return stepErr
+		}
+		assert.Equal(t, stepErr, run())
+
+		require.Len(t, emitted, 3)
+		assert.Equal(t, StepError{StepName: "search", Message: "no results"}, emitted[1].(*CustomEvent).Value)
+	})
+
+	t.Run("joins step and emit errors", func(t *testing.T) {
+		failure := errors.New("connection closed")
+		stepErr := errors.New("no results")
+		done := StepScope(func(event Event) error {
+			if event.Type() == EventTypeStepFinished {
+				return failure
+			}
+			return nil
+		}, "search")
+
+		err := done(stepErr)
+		assert.ErrorIs(t, err, stepErr)
+		assert.ErrorIs(t, err, failure)
+	})
+}
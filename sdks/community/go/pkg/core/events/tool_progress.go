@@ -0,0 +1,69 @@
+package events
+
+import "fmt"
+
+// ToolCallProgressEventName is the CUSTOM event name used for tool call
+// progress updates. It is namespaced so it does not collide with CUSTOM
+// events of other producers.
+const ToolCallProgressEventName = "ag-ui.tool_call_progress"
+
+// ToolCallProgress is the value of a tool call progress event. Current and
+// Total are in whatever unit the tool reports; a Total of zero means the
+// total is unknown.
+type ToolCallProgress struct {
+	ToolCallID string `json:"toolCallId"`
+	Current    int    `json:"current"`
+	Total      int    `json:"total,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Fraction returns the completed fraction between 0 and 1, or -1 when the
+// total is unknown
+func (p ToolCallProgress) Fraction() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return min(max(float64(p.Current)/float64(p.Total), 0), 1)
+}
+
+// NewToolCallProgressEvent creates a CUSTOM event reporting the progress of a
+// running tool call, so frontends can render a progress bar between
+// TOOL_CALL_START and TOOL_CALL_END. Receivers read the update with
+// ToolCallProgressFromEvent.
+func NewToolCallProgressEvent(toolCallID string, current, total int, message string) *CustomEvent {
+	return NewTypedCustomEvent(ToolCallProgressEventName, ToolCallProgress{
+		ToolCallID: toolCallID,
+		Current:    current,
+		Total:      total,
+		Message:    message,
+	})
+}
+
+// ToolCallProgressFromEvent returns the progress carried by a tool call
+// progress event. It reports false for any other event, and for events whose
+// value is not a valid ToolCallProgress.
+func ToolCallProgressFromEvent(event Event) (ToolCallProgress, bool) {
+	custom, ok := event.(*CustomEvent)
+	if !ok || custom.Name != ToolCallProgressEventName {
+		return ToolCallProgress{}, false
+	}
+
+	progress, err := CustomEventValue[ToolCallProgress](custom)
+	if err != nil || progress.Validate() != nil {
+		return ToolCallProgress{}, false
+	}
+	return progress, true
+}
+
+// Validate checks that the progress names a tool call and has non-negative
+// counts. CustomEvent.Validate does not call it, so a malformed progress
+// payload remains a valid CUSTOM event.
+func (p ToolCallProgress) Validate() error {
+	if p.ToolCallID == "" {
+		return fmt.Errorf("%s requires a toolCallId", ToolCallProgressEventName)
+	}
+	if p.Current < 0 || p.Total < 0 {
+		return fmt.Errorf("%s progress cannot be negative", ToolCallProgressEventName)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCallProgressEvent(t *testing.T) {
+	event := NewToolCallProgressEvent("tool-1", 3, 4, "indexing")
+	require.NoError(t, event.Validate())
+	assert.Equal(t, ToolCallProgressEventName, event.Name)
+
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"CUSTOM","timestamp":`+strconv.FormatInt(*event.Timestamp(), 10)+`,"name":"ag-ui.tool_call_progress","value":{"toolCallId":"tool-1","current":3,"total":4,"message":"indexing"}}`, string(data))
+
+	decoded, err := NewEventDecoder(nil).DecodeEvent(string(EventTypeCustom), data)
+	require.NoError(t, err)
+
+	progress, ok := ToolCallProgressFromEvent(decoded)
+	require.True(t, ok)
+	assert.Equal(t, ToolCallProgress{ToolCallID: "tool-1", Current: 3, Total: 4, Message: "indexing"}, progress)
+	assert.Equal(t, 0.75, progress.Fraction())
+
+	_, ok = ToolCallProgressFromEvent(NewCustomEvent("other"))
+	assert.False(t, ok)
+
+	assert.Equal(t, -1.0, ToolCallProgress{Current: 5}.Fraction())
+	assert.Error(t, ToolCallProgress{Current: 1, Total: 2}.Validate())
+	assert.Error(t, ToolCallProgress{ToolCallID: "tool-1", Current: -1, Total: 2}.Validate())
+
+	foreign := NewCustomEvent(ToolCallProgressEventName, WithValue(map[string]any{"percent": 50}))
+	assert.NoError(t, foreign.Validate(), "payloads of other shapes are valid CUSTOM events")
+	_, ok = ToolCallProgressFromEvent(foreign)
+	assert.False(t, ok)
+	_, ok = ToolCallProgressFromEvent(NewToolCallProgressEvent("", 1, 2, ""))
+	assert.False(t, ok)
+
+	seq := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewToolCallStartEvent("tool-1", "index"),
+		NewToolCallProgressEvent("tool-1", 1, 2, ""),
+		NewToolCallProgressEvent("tool-1", 2, 2, ""),
+		NewToolCallEndEvent("tool-1"),
+	}
+	assert.NoError(t, ValidateSequence(seq))
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// TraceContextExtractor returns the trace and span IDs of the span active in
+// ctx, and false if there is none
+type TraceContextExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+// traceContextKey is the context key used by ContextWithTrace
+type traceContextKey struct{}
+
+type traceContext struct {
+	traceID string
+	spanID  string
+}
+
+var (
+	traceExtractorMu sync.RWMutex
+	traceExtractor   TraceContextExtractor = traceFromContext
+)
+
+// ContextWithTrace returns a copy of ctx carrying the given trace and span
+// IDs, for use with WithTraceContext when no tracing library is installed
+func ContextWithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceContext{traceID: traceID, spanID: spanID})
+}
+
+// SetTraceContextExtractor replaces how WithTraceContext finds the active
+// span. The SDK does not depend on a tracing library; OpenTelemetry users
+// install an extractor once at startup:
+//
+//	events.SetTraceContextExtractor(func(ctx context.Context) (string, string, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return sc.TraceID().String(), sc.SpanID().String(), sc.IsValid()
+//	})
+//
+// A nil extractor restores the default, which reads IDs stored with
+// ContextWithTrace.
+func SetTraceContextExtractor(extractor TraceContextExtractor) {
+	if extractor == nil {
+		extractor = traceFromContext
+	}
+
+	traceExtractorMu.Lock()
+	defer traceExtractorMu.Unlock()
+	traceExtractor = extractor
+}
+
+// WithTraceContext sets the trace and span IDs of event from the span active
+// in ctx and returns the event. Events are left unchanged when ctx carries no
+// span.
+func WithTraceContext[E Event](ctx context.Context, event E) E {
+	traceExtractorMu.RLock()
+	extract := traceExtractor
+	traceExtractorMu.RUnlock()
+
+	if traceID, spanID, ok := extract(ctx); ok {
+		if base := event.GetBaseEvent(); base != nil {
+			base.TraceID = traceID
+			base.SpanID = spanID
+		}
+	}
+	return event
+}
+
+func traceFromContext(ctx context.Context) (traceID, spanID string, ok bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(traceContext)
+	return tc.traceID, tc.spanID, ok
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTraceContext(t *testing.T) {
+	t.Run("sets IDs from the context", func(t *testing.T) {
+		ctx := ContextWithTrace(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+		event := WithTraceContext(ctx, NewRunStartedEvent("thread-1", "run-1"))
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", event.TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", event.SpanID)
+
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+		assert.Contains(t, string(data), `"spanId":"00f067aa0ba902b7"`)
+
+		decoded, err := EventFromJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", decoded.GetBaseEvent().TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", decoded.GetBaseEvent().SpanID)
+	})
+
+	t.Run("absent trace adds no JSON keys", func(t *testing.T) {
+		event := WithTraceContext(context.Background(), NewStepStartedEvent("plan"))
+		assert.Empty(t, event.TraceID)
+
+		data, err := event.ToJSON()
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "traceId")
+		assert.NotContains(t, string(data), "spanId")
+	})
+
+	t.Run("custom extractor", func(t *testing.T) {
+		SetTraceContextExtractor(func(context.Context) (string, string, bool) {
+			return "trace-1", "span-1", true
+		})
+		t.Cleanup(func() { SetTraceContextExtractor(nil) })
+
+		event := WithTraceContext(context.Background(), NewTextMessageEndEvent("msg-1"))
+		assert.Equal(t, "trace-1", event.TraceID)
+		assert.Equal(t, "span-1", event.SpanID)
+	})
+
+	t.Run("released events drop trace IDs", func(t *testing.T) {
+		ctx := ContextWithTrace(context.Background(), "trace-1", "span-1")
+		Release(WithTraceContext(ctx, AcquireTextMessageContentEvent("msg-1", "hi")))
+
+		event := AcquireTextMessageContentEvent("msg-2", "hi")
+		assert.Empty(t, event.TraceID)
+		assert.Empty(t, event.SpanID)
+	})
+}
@@ -0,0 +1,673 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	agerrors "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/errors"
+)
+
+// ValidationConfig selects the optional rules a Validator applies on top of
+// the protocol's sequence rules. The zero value enables none of them.
+type ValidationConfig struct {
+	// ValidateToolCallArgsJSON accumulates TOOL_CALL_ARGS deltas per tool
+	// call and requires the concatenated arguments to parse as JSON at
+	// TOOL_CALL_END. It is opt-in because some producers stream arguments
+	// that are only meaningful incrementally. Tool calls without any args
+	// are accepted.
+	ValidateToolCallArgsJSON bool
+
+	// MaxToolCallArgsBytes rejects TOOL_CALL_ARGS events that would grow
+	// the arguments of a tool call beyond this many bytes, with a
+	// ToolCallArgsTooLargeError, so an argument flood cannot exhaust
+	// memory. Zero means no limit.
+	MaxToolCallArgsBytes int
+
+	// RequireActiveRun rejects events that arrive while no run is active.
+	// RUN_STARTED and RUN_ERROR are exempt, since a run may fail before it
+	// starts.
+	RequireActiveRun bool
+
+	// RejectFutureTimestamps rejects events timestamped more than
+	// MaxClockSkew ahead of the validator's clock. Events without a
+	// timestamp are accepted.
+	RejectFutureTimestamps bool
+	MaxClockSkew           time.Duration
+
+	// AllowEmptyIDs accepts run, message and tool call events whose IDs
+	// are empty instead of failing their structural validation. Sequence
+	// rules still apply, with the empty string tracked as an ID.
+	AllowEmptyIDs bool
+
+	// VerboseErrors appends the offending event's JSON to validation
+	// errors.
+	VerboseErrors bool
+
+	// AllowedRoles restricts the roles that TEXT_MESSAGE_START,
+	// TEXT_MESSAGE_CHUNK and MESSAGES_SNAPSHOT may carry. Empty allows every
+	// role.
+	AllowedRoles []string
+
+	// ValidateIDFormat rejects thread, run, message and tool call IDs with
+	// leading or trailing whitespace or control characters, IDs longer than
+	// MaxIDLength bytes when it is positive, and IDs that do not match
+	// IDPattern when it is set. Empty IDs are governed by the events' own
+	// validation and AllowEmptyIDs. NormalizeIDs trims IDs before
+	// validation if padded IDs should be repaired rather than rejected.
+	ValidateIDFormat bool
+	MaxIDLength      int
+	IDPattern        *regexp.Regexp
+
+	// RequireCustomEventSchema rejects CUSTOM events without a schema URI,
+	// for pipelines that only accept self-describing events. See
+	// CustomEvent.WithSchemaURI.
+	RequireCustomEventSchema bool
+
+	// CustomValidators are application-specific rules run, in order, on
+	// every event that passes the protocol rules. A validator that panics
+	// fails the event with a ValidationError for RuleCustomValidatorPanic
+	// instead of aborting validation.
+	CustomValidators []CustomValidator
+}
+
+// RuleCustomValidatorPanic is the rule of the ValidationError reported when
+// a custom validator panics
+const RuleCustomValidatorPanic = "CUSTOM_VALIDATOR_PANIC"
+
+// CustomValidator is an application-specific validation rule
+type CustomValidator interface {
+	// Name identifies the validator in errors
+	Name() string
+	// Validate returns an error if the event breaks the rule
+	Validate(event Event) error
+}
+
+// NewCustomValidator returns a CustomValidator that calls fn
+func NewCustomValidator(name string, fn func(Event) error) CustomValidator {
+	return customValidatorFunc{name: name, fn: fn}
+}
+
+type customValidatorFunc struct {
+	name string
+	fn   func(Event) error
+}
+
+func (c customValidatorFunc) Name() string {
+	return c.name
+}
+
+func (c customValidatorFunc) Validate(event Event) error {
+	return c.fn(event)
+}
+
+// DefaultValidationConfig returns the configuration used by ValidateSequence
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{}
+}
+
+// ProductionValidationConfig returns a strict baseline for production
+// traffic: events must belong to an active run, timestamps may not be more
+// than five seconds in the future, and all IDs are required and free of
+// stray whitespace and control characters. Override individual fields as
+// needed.
+func ProductionValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		RequireActiveRun:       true,
+		RejectFutureTimestamps: true,
+		MaxClockSkew:           5 * time.Second,
+		ValidateIDFormat:       true,
+	}
+}
+
+// DevelopmentValidationConfig returns a permissive baseline for local
+// development: empty IDs are accepted and errors include the offending
+// event. Sequence rules still apply. Override individual fields as needed.
+func DevelopmentValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		AllowEmptyIDs: true,
+		VerboseErrors: true,
+	}
+}
+
+// ToolCallArgsError reports tool call arguments that are not valid JSON
+type ToolCallArgsError struct {
+	ToolCallID string
+	// Offset is the byte offset into the accumulated arguments at which
+	// parsing failed
+	Offset int64
+	Err    error
+}
+
+func (e *ToolCallArgsError) Error() string {
+	return fmt.Sprintf("tool call %s arguments are not valid JSON at offset %d: %v", e.ToolCallID, e.Offset, e.Err)
+}
+
+func (e *ToolCallArgsError) Unwrap() error {
+	return e.Err
+}
+
+// ToolCallArgsTooLargeError reports tool call arguments that exceed
+// ValidationConfig.MaxToolCallArgsBytes
+type ToolCallArgsTooLargeError struct {
+	ToolCallID string
+	Limit      int
+}
+
+func (e *ToolCallArgsTooLargeError) Error() string {
+	return fmt.Sprintf("tool call %s arguments exceed limit of %d bytes", e.ToolCallID, e.Limit)
+}
+
+// Validator validates events one at a time against AG-UI protocol sequence
+// rules, keeping track of active runs, steps, messages and tool calls
+// between calls. Use it to validate a live stream incrementally; for a
+// complete slice of events ValidateSequence is simpler. A Validator is not
+// safe for concurrent use.
+type Validator struct {
+	config       ValidationConfig
+	index        int
+	now          func() time.Time // injectable clock for tests
+	allowedRoles map[string]bool  // nil when every role is allowed
+
+	activeRuns              map[string]bool
+	activeMessages          map[string]bool
+	messageRoles            map[string]string // role fixed by the first event of each message, cleared when the last active run ends or, outside runs, when the message closes
+	activeReasoningMessages map[string]bool
+	activeToolCalls         map[string]bool
+	activeSteps             map[string]bool
+	finishedRuns            map[string]bool
+	toolCallArgs            map[string]*strings.Builder
+	toolCallArgsBytes       map[string]int
+	warnings                []Warning
+}
+
+// NewValidator creates a validator with the given configuration
+func NewValidator(config ValidationConfig) *Validator {
+	v := &Validator{config: config, now: clockNow}
+	if len(config.AllowedRoles) > 0 {
+		v.allowedRoles = make(map[string]bool, len(config.AllowedRoles))
+		for _, role := range config.AllowedRoles {
+			v.allowedRoles[role] = true
+		}
+	}
+	v.Reset()
+	return v
+}
+
+// Reset clears all sequence state so the validator can be reused for a new
+// stream
+func (v *Validator) Reset() {
+	v.index = 0
+	v.activeRuns = make(map[string]bool)
+	v.activeMessages = make(map[string]bool)
+	v.messageRoles = make(map[string]string)
+	v.activeReasoningMessages = make(map[string]bool)
+	v.activeToolCalls = make(map[string]bool)
+	v.activeSteps = make(map[string]bool)
+	v.finishedRuns = make(map[string]bool)
+	v.toolCallArgs = make(map[string]*strings.Builder)
+	v.toolCallArgsBytes = make(map[string]int)
+	v.warnings = nil
+}
+
+// Warnings returns the non-fatal findings recorded since the validator was
+// created or last reset, such as uses of fields registered with
+// DeprecateField
+func (v *Validator) Warnings() []Warning {
+	return append([]Warning(nil), v.warnings...)
+}
+
+// ValidateEvent validates the next event in the stream. The event is
+// validated on its own first, then against the state built from the events
+// seen so far.
+func (v *Validator) ValidateEvent(event Event) error {
+	i := v.index
+	v.index++
+
+	if event == nil {
+		return fmt.Errorf("event %d validation failed: event is nil", i)
+	}
+
+	v.warnings = append(v.warnings, deprecationWarnings(i, event)...)
+
+	err := v.validateEvent(i, event)
+	if err != nil && v.config.VerboseErrors {
+		if data, jsonErr := event.ToJSON(); jsonErr == nil {
+			return fmt.Errorf("%w\n\tevent: %s", err, data)
+		}
+	}
+	return err
+}
+
+func (v *Validator) validateEvent(i int, event Event) error {
+	validate := event.Validate
+	if v.config.AllowEmptyIDs {
+		validate = withPlaceholderIDs(event).Validate
+	}
+	if err := validate(); err != nil {
+		return fmt.Errorf("event %d validation failed: %w", i, err)
+	}
+
+	if v.config.RejectFutureTimestamps {
+		if ts := event.Timestamp(); ts != nil {
+			limit := v.now().Add(v.config.MaxClockSkew)
+			if time.UnixMilli(*ts).After(limit) {
+				return fmt.Errorf("event %d validation failed: timestamp %d is in the future", i, *ts)
+			}
+		}
+	}
+
+	if v.config.ValidateIDFormat {
+		if err := checkIDFormat(event, v.config.MaxIDLength, v.config.IDPattern); err != nil {
+			return fmt.Errorf("event %d validation failed: %w", i, err)
+		}
+	}
+
+	if v.config.RequireActiveRun && len(v.activeRuns) == 0 {
+		switch event.Type() {
+		case EventTypeRunStarted, EventTypeRunError:
+		default:
+			return fmt.Errorf("event %d validation failed: %s event outside of an active run", i, event.Type())
+		}
+	}
+
+	// Check sequence-specific validation rules
+	switch event.Type() {
+	case EventTypeRunStarted:
+		if runEvent, ok := event.(*RunStartedEvent); ok {
+			if v.activeRuns[runEvent.RunID()] {
+				return fmt.Errorf("run %s already started", runEvent.RunID())
+			}
+			if v.finishedRuns[runEvent.RunID()] {
+				return fmt.Errorf("cannot restart finished run %s", runEvent.RunID())
+			}
+			v.activeRuns[runEvent.RunID()] = true
+		}
+
+	case EventTypeRunFinished:
+		if runEvent, ok := event.(*RunFinishedEvent); ok {
+			if !v.activeRuns[runEvent.RunID()] {
+				return fmt.Errorf("cannot finish run %s that was not started", runEvent.RunID())
+			}
+			delete(v.activeRuns, runEvent.RunID())
+			v.finishedRuns[runEvent.RunID()] = true
+			v.forgetMessageRolesIfIdle()
+		}
+
+	case EventTypeRunError:
+		if runEvent, ok := event.(*RunErrorEvent); ok {
+			if runEvent.RunID() != "" && !v.activeRuns[runEvent.RunID()] {
+				return fmt.Errorf("cannot error run %s that was not started", runEvent.RunID())
+			}
+			if runEvent.RunID() != "" {
+				delete(v.activeRuns, runEvent.RunID())
+				v.finishedRuns[runEvent.RunID()] = true
+			}
+			v.forgetMessageRolesIfIdle()
+		}
+
+	case EventTypeStepStarted:
+		if stepEvent, ok := event.(*StepStartedEvent); ok {
+			if v.activeSteps[stepEvent.StepName] {
+				return fmt.Errorf("step %s already started", stepEvent.StepName)
+			}
+			v.activeSteps[stepEvent.StepName] = true
+		}
+
+	case EventTypeStepFinished:
+		if stepEvent, ok := event.(*StepFinishedEvent); ok {
+			if !v.activeSteps[stepEvent.StepName] {
+				return fmt.Errorf("cannot finish step %s that was not started", stepEvent.StepName)
+			}
+			delete(v.activeSteps, stepEvent.StepName)
+		}
+
+	case EventTypeTextMessageStart:
+		if msgEvent, ok := event.(*TextMessageStartEvent); ok {
+			if v.activeMessages[msgEvent.MessageID] {
+				return fmt.Errorf("message %s already started", msgEvent.MessageID)
+			}
+			role := "assistant"
+			if msgEvent.Role != nil {
+				role = *msgEvent.Role
+			}
+			if err := v.checkAllowedRole(msgEvent.MessageID, role); err != nil {
+				return err
+			}
+			if err := v.checkMessageRole(msgEvent.MessageID, role); err != nil {
+				return err
+			}
+			v.activeMessages[msgEvent.MessageID] = true
+		}
+
+	case EventTypeTextMessageContent:
+		if msgEvent, ok := event.(*TextMessageContentEvent); ok {
+			if !v.activeMessages[msgEvent.MessageID] {
+				return fmt.Errorf("cannot add content to message %s that was not started", msgEvent.MessageID)
+			}
+			// Content events are valid between start and end
+		}
+
+	case EventTypeTextMessageEnd:
+		if msgEvent, ok := event.(*TextMessageEndEvent); ok {
+			if !v.activeMessages[msgEvent.MessageID] {
+				return fmt.Errorf("cannot end message %s that was not started", msgEvent.MessageID)
+			}
+			delete(v.activeMessages, msgEvent.MessageID)
+			v.forgetMessageRoleIfIdle(msgEvent.MessageID)
+		}
+
+	case EventTypeTextMessageChunk:
+		if chunkEvent, ok := event.(*TextMessageChunkEvent); ok {
+			if chunkEvent.Role != nil {
+				messageID := ""
+				if chunkEvent.MessageID != nil {
+					messageID = *chunkEvent.MessageID
+				}
+				if err := v.checkAllowedRole(messageID, *chunkEvent.Role); err != nil {
+					return err
+				}
+			}
+			if chunkEvent.MessageID != nil && chunkEvent.Role != nil {
+				if err := v.checkMessageRole(*chunkEvent.MessageID, *chunkEvent.Role); err != nil {
+					return err
+				}
+				v.forgetMessageRoleIfIdle(*chunkEvent.MessageID)
+			}
+		}
+
+	case EventTypeToolCallStart:
+		if toolEvent, ok := event.(*ToolCallStartEvent); ok {
+			if v.activeToolCalls[toolEvent.ToolCallID] {
+				return fmt.Errorf("tool call %s already started", toolEvent.ToolCallID)
+			}
+			v.activeToolCalls[toolEvent.ToolCallID] = true
+			if v.config.ValidateToolCallArgsJSON {
+				v.toolCallArgs[toolEvent.ToolCallID] = &strings.Builder{}
+			}
+		}
+
+	case EventTypeToolCallArgs:
+		if toolEvent, ok := event.(*ToolCallArgsEvent); ok {
+			if !v.activeToolCalls[toolEvent.ToolCallID] {
+				return fmt.Errorf("cannot add args to tool call %s that was not started", toolEvent.ToolCallID)
+			}
+			// Args events are valid between start and end
+			if limit := v.config.MaxToolCallArgsBytes; limit > 0 {
+				size := v.toolCallArgsBytes[toolEvent.ToolCallID] + len(toolEvent.Delta)
+				if size > limit {
+					return fmt.Errorf("event %d validation failed: %w", i, &ToolCallArgsTooLargeError{ToolCallID: toolEvent.ToolCallID, Limit: limit})
+				}
+				v.toolCallArgsBytes[toolEvent.ToolCallID] = size
+			}
+			if args, ok := v.toolCallArgs[toolEvent.ToolCallID]; ok {
+				args.WriteString(toolEvent.Delta)
+			}
+		}
+
+	case EventTypeToolCallEnd:
+		if toolEvent, ok := event.(*ToolCallEndEvent); ok {
+			if !v.activeToolCalls[toolEvent.ToolCallID] {
+				return fmt.Errorf("cannot end tool call %s that was not started", toolEvent.ToolCallID)
+			}
+			delete(v.activeToolCalls, toolEvent.ToolCallID)
+			delete(v.toolCallArgsBytes, toolEvent.ToolCallID)
+			if args, ok := v.toolCallArgs[toolEvent.ToolCallID]; ok {
+				delete(v.toolCallArgs, toolEvent.ToolCallID)
+				if err := validateToolCallArgs(toolEvent.ToolCallID, args.String()); err != nil {
+					return fmt.Errorf("event %d validation failed: %w", i, err)
+				}
+			}
+		}
+
+	case EventTypeToolCallChunk:
+		// Chunk events are always valid in sequence context.
+
+	case EventTypeToolCallResult:
+		if resultEvent, ok := event.(*ToolCallResultEvent); ok {
+			role := "tool"
+			if resultEvent.Role != nil {
+				role = *resultEvent.Role
+			}
+			if err := v.checkMessageRole(resultEvent.MessageID, role); err != nil {
+				return err
+			}
+			v.forgetMessageRoleIfIdle(resultEvent.MessageID)
+		}
+
+	case EventTypeReasoningStart:
+		// Reasoning events are always valid in sequence context.
+
+	case EventTypeReasoningMessageStart:
+		if msgEvent, ok := event.(*ReasoningMessageStartEvent); ok {
+			if v.activeReasoningMessages[msgEvent.MessageID] {
+				return fmt.Errorf("reasoning message %s already started", msgEvent.MessageID)
+			}
+			v.activeReasoningMessages[msgEvent.MessageID] = true
+		}
+
+	case EventTypeReasoningMessageContent:
+		if msgEvent, ok := event.(*ReasoningMessageContentEvent); ok {
+			if !v.activeReasoningMessages[msgEvent.MessageID] {
+				return fmt.Errorf("cannot add content to reasoning message %s that was not started", msgEvent.MessageID)
+			}
+		}
+
+	case EventTypeReasoningMessageEnd:
+		if msgEvent, ok := event.(*ReasoningMessageEndEvent); ok {
+			if !v.activeReasoningMessages[msgEvent.MessageID] {
+				return fmt.Errorf("cannot end reasoning message %s that was not started", msgEvent.MessageID)
+			}
+			delete(v.activeReasoningMessages, msgEvent.MessageID)
+		}
+
+	case EventTypeReasoningMessageChunk:
+		// Chunk events are always valid in sequence context.
+
+	case EventTypeReasoningEncryptedValue:
+		// Encrypted value events are always valid in sequence context.
+
+	case EventTypeReasoningEnd:
+		// Reasoning events are always valid in sequence context.
+
+	case EventTypeStateSnapshot:
+		// State snapshot events are always valid in sequence context
+		// They represent complete state at any point in time
+		// Additional validation could be added if needed (e.g., frequency limits)
+
+	case EventTypeStateDelta:
+		// State delta events are always valid in sequence context
+		// They represent incremental changes at any point in time
+		// Additional validation could be added if needed (e.g., conflict detection)
+
+	case EventTypeMessagesSnapshot:
+		// Message snapshot events are always valid in sequence context
+		// They represent complete message state at any point in time
+		if snapshotEvent, ok := event.(*MessagesSnapshotEvent); ok {
+			for _, msg := range snapshotEvent.Messages {
+				if err := v.checkAllowedRole(msg.ID, string(msg.Role)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case EventTypeActivitySnapshot:
+		// Activity snapshot events are always valid in sequence context
+		// They represent complete activity state at any point in time
+
+	case EventTypeActivityDelta:
+		// Activity delta events are always valid in sequence context
+		// They represent incremental activity changes at any point in time
+
+	case EventTypeRaw:
+		// Raw events are always valid in sequence context
+		// They contain external data that should be passed through
+		// Additional validation could be added via custom validators
+
+	case EventTypeCustom:
+		// Custom events are always valid in sequence context
+		// They contain application-specific data
+		// Additional validation could be added via custom validators
+		if customEvent, ok := event.(*CustomEvent); ok && v.config.RequireCustomEventSchema && customEvent.SchemaURI == "" {
+			return fmt.Errorf("custom event %s has no schema URI", customEvent.Name)
+		}
+
+	default:
+		// This should not happen due to prior validation, but add safety check
+		if event.Type().Category() == CategoryUnknown {
+			return fmt.Errorf("unknown event type in sequence: %s", event.Type())
+		}
+		// Other known types, such as the deprecated thinking events, are
+		// always valid in sequence context.
+	}
+
+	for _, custom := range v.config.CustomValidators {
+		if err := runCustomValidator(custom, event); err != nil {
+			return fmt.Errorf("event %d validation failed: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// runCustomValidator runs a custom validator, converting a panic into a
+// ValidationError attributed to it
+func runCustomValidator(custom CustomValidator, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			validationErr := agerrors.NewValidationError(RuleCustomValidatorPanic,
+				fmt.Sprintf("custom validator %s panicked: %v", custom.Name(), r)).
+				WithRule(RuleCustomValidatorPanic).
+				WithDetail("validator", custom.Name())
+			validationErr.Severity = agerrors.SeverityError
+			err = validationErr
+		}
+	}()
+
+	if err := custom.Validate(event); err != nil {
+		return fmt.Errorf("custom validator %s: %w", custom.Name(), err)
+	}
+	return nil
+}
+
+// checkAllowedRole rejects roles outside of ValidationConfig.AllowedRoles
+func (v *Validator) checkAllowedRole(messageID, role string) error {
+	if v.allowedRoles == nil || v.allowedRoles[role] {
+		return nil
+	}
+	return fmt.Errorf("message %s has disallowed role %q", messageID, role)
+}
+
+// forgetMessageRolesIfIdle drops the recorded message roles once no run is
+// active, so a long-lived validator does not keep one entry per message ever
+// seen
+func (v *Validator) forgetMessageRolesIfIdle() {
+	if len(v.activeRuns) == 0 {
+		clear(v.messageRoles)
+	}
+}
+
+// forgetMessageRoleIfIdle drops the role of a message that is not open while
+// no run is active. Outside runs there is no RUN_FINISHED to clear the map,
+// so only open messages keep their entry.
+func (v *Validator) forgetMessageRoleIfIdle(messageID string) {
+	if len(v.activeRuns) == 0 && !v.activeMessages[messageID] {
+		delete(v.messageRoles, messageID)
+	}
+}
+
+// checkMessageRole records the role of a message the first time it is seen
+// and rejects any later event that implies a different role for it
+func (v *Validator) checkMessageRole(messageID, role string) error {
+	declared, ok := v.messageRoles[messageID]
+	if !ok {
+		v.messageRoles[messageID] = role
+		return nil
+	}
+	if declared != role {
+		return fmt.Errorf("message %s has role %q but was started with role %q", messageID, role, declared)
+	}
+	return nil
+}
+
+// emptyIDPlaceholder stands in for empty IDs when AllowEmptyIDs is set
+const emptyIDPlaceholder = "<empty>"
+
+// withPlaceholderIDs returns a shallow copy of event with empty required IDs
+// replaced by a placeholder, so that structural validation checks every rule
+// except ID presence. Events without required IDs are returned unchanged.
+func withPlaceholderIDs(event Event) Event {
+	fill := func(id *string) {
+		if *id == "" {
+			*id = emptyIDPlaceholder
+		}
+	}
+
+	switch e := event.(type) {
+	case *RunStartedEvent:
+		c := *e
+		fill(&c.ThreadIDValue)
+		fill(&c.RunIDValue)
+		return &c
+	case *RunFinishedEvent:
+		c := *e
+		fill(&c.ThreadIDValue)
+		fill(&c.RunIDValue)
+		return &c
+	case *TextMessageStartEvent:
+		c := *e
+		fill(&c.MessageID)
+		return &c
+	case *TextMessageContentEvent:
+		c := *e
+		fill(&c.MessageID)
+		return &c
+	case *TextMessageEndEvent:
+		c := *e
+		fill(&c.MessageID)
+		return &c
+	case *ToolCallStartEvent:
+		c := *e
+		fill(&c.ToolCallID)
+		return &c
+	case *ToolCallArgsEvent:
+		c := *e
+		fill(&c.ToolCallID)
+		return &c
+	case *ToolCallEndEvent:
+		c := *e
+		fill(&c.ToolCallID)
+		return &c
+	case *ToolCallResultEvent:
+		c := *e
+		fill(&c.MessageID)
+		fill(&c.ToolCallID)
+		return &c
+	}
+	return event
+}
+
+// validateToolCallArgs checks that the accumulated arguments of a tool call
+// parse as JSON
+func validateToolCallArgs(toolCallID, args string) error {
+	if strings.TrimSpace(args) == "" {
+		return nil
+	}
+
+	var parsed any
+	err := json.Unmarshal([]byte(args), &parsed)
+	if err == nil {
+		return nil
+	}
+
+	offset := int64(len(args))
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	}
+
+	return &ToolCallArgsError{ToolCallID: toolCallID, Offset: offset, Err: err}
+}
@@ -0,0 +1,508 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	coretypes "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	agerrors "github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toolCallSequence(toolCallID string, deltas ...string) []Event {
+	seq := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewToolCallStartEvent(toolCallID, "search"),
+	}
+	for _, delta := range deltas {
+		seq = append(seq, NewToolCallArgsEvent(toolCallID, delta))
+	}
+	return append(seq,
+		NewToolCallEndEvent(toolCallID),
+		NewRunFinishedEvent("thread-1", "run-1"),
+	)
+}
+
+func TestValidator(t *testing.T) {
+	t.Run("incremental validation tracks state across calls", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, v.ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("assistant"))))
+		require.NoError(t, v.ValidateEvent(NewTextMessageContentEvent("msg-1", "hi")))
+
+		err := v.ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("assistant")))
+		assert.EqualError(t, err, "message msg-1 already started")
+	})
+
+	t.Run("event validation errors carry the stream index", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+
+		err := v.ValidateEvent(NewTextMessageContentEvent("msg-1", ""))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "event 1 validation failed")
+
+		assert.Error(t, v.ValidateEvent(nil))
+	})
+
+	t.Run("Reset clears sequence state", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, v.ValidateEvent(NewRunFinishedEvent("thread-1", "run-1")))
+		require.Error(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+
+		v.Reset()
+		assert.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+	})
+}
+
+func TestValidateToolCallArgsJSON(t *testing.T) {
+	strict := ValidationConfig{ValidateToolCallArgsJSON: true}
+
+	t.Run("accepts arguments that form valid JSON", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{"query":`, `"ag-ui",`, `"limit":5}`)
+		assert.NoError(t, ValidateSequenceWithConfig(seq, strict))
+	})
+
+	t.Run("accepts tool calls without arguments", func(t *testing.T) {
+		assert.NoError(t, ValidateSequenceWithConfig(toolCallSequence("tool-1"), strict))
+	})
+
+	t.Run("flags malformed arguments with tool call ID and offset", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{"query":`, `"ag-ui",,}`)
+
+		err := ValidateSequenceWithConfig(seq, strict)
+		require.Error(t, err)
+
+		var argsErr *ToolCallArgsError
+		require.True(t, errors.As(err, &argsErr))
+		assert.Equal(t, "tool-1", argsErr.ToolCallID)
+		assert.Equal(t, int64(18), argsErr.Offset)
+		assert.Contains(t, err.Error(), "event 4 validation failed: tool call tool-1 arguments are not valid JSON at offset 18")
+
+		var syntaxErr *json.SyntaxError
+		assert.True(t, errors.As(err, &syntaxErr))
+	})
+
+	t.Run("flags truncated arguments", func(t *testing.T) {
+		err := ValidateSequenceWithConfig(toolCallSequence("tool-1", `{"query":"ag`), strict)
+
+		var argsErr *ToolCallArgsError
+		require.True(t, errors.As(err, &argsErr))
+		assert.Equal(t, int64(len(`{"query":"ag`)), argsErr.Offset)
+	})
+
+	t.Run("tracks arguments per tool call", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewToolCallStartEvent("tool-1", "search"),
+			NewToolCallStartEvent("tool-2", "fetch"),
+			NewToolCallArgsEvent("tool-1", `{"a":`),
+			NewToolCallArgsEvent("tool-2", `{"b":2}`),
+			NewToolCallArgsEvent("tool-1", `1}`),
+			NewToolCallEndEvent("tool-2"),
+			NewToolCallEndEvent("tool-1"),
+		}
+		assert.NoError(t, ValidateSequenceWithConfig(seq, strict))
+	})
+
+	t.Run("is opt-in", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{"partial":`)
+		assert.NoError(t, ValidateSequence(seq))
+	})
+}
+
+func TestMaxToolCallArgsBytes(t *testing.T) {
+	limited := ValidationConfig{MaxToolCallArgsBytes: 20}
+
+	t.Run("accepts arguments within the limit", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{"query":`, `"ag-ui"}`)
+		assert.NoError(t, ValidateSequenceWithConfig(seq, limited))
+	})
+
+	t.Run("flags arguments beyond the limit with tool call ID and limit", func(t *testing.T) {
+		seq := toolCallSequence("tool-1", `{"query":`, `"ag-ui protocol"}`)
+
+		err := ValidateSequenceWithConfig(seq, limited)
+		var sizeErr *ToolCallArgsTooLargeError
+		require.True(t, errors.As(err, &sizeErr))
+		assert.Equal(t, "tool-1", sizeErr.ToolCallID)
+		assert.Equal(t, 20, sizeErr.Limit)
+		assert.Contains(t, err.Error(), "event 3 validation failed: tool call tool-1 arguments exceed limit of 20 bytes")
+	})
+
+	t.Run("counts each tool call separately", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewToolCallStartEvent("tool-1", "search"),
+			NewToolCallStartEvent("tool-2", "fetch"),
+			NewToolCallArgsEvent("tool-1", `{"a":"12345678"}`),
+			NewToolCallArgsEvent("tool-2", `{"b":"12345678"}`),
+			NewToolCallEndEvent("tool-1"),
+			NewToolCallEndEvent("tool-2"),
+		}
+		assert.NoError(t, ValidateSequenceWithConfig(seq, limited))
+	})
+}
+
+func TestValidationConfigPresets(t *testing.T) {
+	t.Run("production requires an active run", func(t *testing.T) {
+		v := NewValidator(ProductionValidationConfig())
+
+		err := v.ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("assistant")))
+		assert.EqualError(t, err, "event 0 validation failed: TEXT_MESSAGE_START event outside of an active run")
+
+		require.NoError(t, v.ValidateEvent(NewRunErrorEvent("failed before start")))
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+		require.NoError(t, v.ValidateEvent(NewTextMessageStartEvent("msg-1", WithRole("assistant"))))
+	})
+
+	t.Run("production rejects future timestamps", func(t *testing.T) {
+		now := time.UnixMilli(1700000000000)
+		v := NewValidator(ProductionValidationConfig())
+		v.now = func() time.Time { return now }
+
+		withinSkew := NewRunStartedEvent("thread-1", "run-1")
+		withinSkew.SetTimestamp(now.Add(4 * time.Second).UnixMilli())
+		require.NoError(t, v.ValidateEvent(withinSkew))
+
+		future := NewStepStartedEvent("plan")
+		future.SetTimestamp(now.Add(time.Minute).UnixMilli())
+		err := v.ValidateEvent(future)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is in the future")
+	})
+
+	t.Run("production requires IDs", func(t *testing.T) {
+		v := NewValidator(ProductionValidationConfig())
+		assert.Error(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "")))
+	})
+
+	t.Run("development allows empty IDs", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("", ""),
+			NewTextMessageStartEvent("", WithRole("assistant")),
+			NewTextMessageContentEvent("", "hi"),
+			NewTextMessageEndEvent(""),
+			NewToolCallStartEvent("", "search"),
+			NewToolCallEndEvent(""),
+			NewRunFinishedEvent("", ""),
+		}
+		require.NoError(t, ValidateSequenceWithConfig(seq, DevelopmentValidationConfig()))
+		assert.Error(t, ValidateSequence(seq))
+
+		// Other structural rules still apply
+		err := ValidateSequenceWithConfig([]Event{NewToolCallStartEvent("", "")}, DevelopmentValidationConfig())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "toolCallName field is required")
+	})
+
+	t.Run("development errors include the event", func(t *testing.T) {
+		v := NewValidator(DevelopmentValidationConfig())
+		err := v.ValidateEvent(NewTextMessageContentEvent("msg-1", "hi"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot add content to message msg-1 that was not started")
+		assert.Contains(t, err.Error(), `"delta":"hi"`)
+	})
+
+	t.Run("presets can be overridden", func(t *testing.T) {
+		config := ProductionValidationConfig()
+		config.RequireActiveRun = false
+		assert.NoError(t, NewValidator(config).ValidateEvent(NewStepStartedEvent("plan")))
+	})
+}
+
+func TestValidateMessageRole(t *testing.T) {
+	start := []Event{
+		NewRunStartedEvent("thread-1", "run-1"),
+		NewTextMessageStartEvent("msg-1", WithRole("assistant")),
+	}
+
+	t.Run("chunk with the declared role is accepted", func(t *testing.T) {
+		seq := append(append([]Event{}, start...),
+			NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID("msg-1").WithChunkRole("assistant").WithChunkDelta("hi"),
+		)
+		assert.NoError(t, ValidateSequence(seq))
+	})
+
+	t.Run("chunk with a different role is flagged", func(t *testing.T) {
+		seq := append(append([]Event{}, start...),
+			NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID("msg-1").WithChunkRole("user").WithChunkDelta("hi"),
+		)
+		assert.EqualError(t, ValidateSequence(seq), `message msg-1 has role "user" but was started with role "assistant"`)
+	})
+
+	t.Run("message without a role defaults to assistant", func(t *testing.T) {
+		seq := []Event{
+			NewRunStartedEvent("thread-1", "run-1"),
+			NewTextMessageStartEvent("msg-1"),
+			NewTextMessageEndEvent("msg-1"),
+			NewToolCallResultEvent("msg-1", "tool-1", "done"),
+		}
+		assert.EqualError(t, ValidateSequence(seq), `message msg-1 has role "tool" but was started with role "assistant"`)
+	})
+
+	t.Run("restarting a message with another role is flagged", func(t *testing.T) {
+		seq := append(append([]Event{}, start...),
+			NewTextMessageEndEvent("msg-1"),
+			NewTextMessageStartEvent("msg-1", WithRole("user")),
+		)
+		assert.Error(t, ValidateSequence(seq))
+	})
+
+	t.Run("content and end require a started message", func(t *testing.T) {
+		run := NewRunStartedEvent("thread-1", "run-1")
+		assert.EqualError(t, ValidateSequence([]Event{run, NewTextMessageContentEvent("msg-9", "x")}),
+			"cannot add content to message msg-9 that was not started")
+		assert.EqualError(t, ValidateSequence([]Event{run, NewTextMessageEndEvent("msg-9")}),
+			"cannot end message msg-9 that was not started")
+	})
+
+	t.Run("roles are forgotten when the last run ends", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+		for i := 0; i < 3; i++ {
+			runID := fmt.Sprintf("run-%d", i)
+			messageID := fmt.Sprintf("msg-%d", i)
+			require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", runID)))
+			require.NoError(t, v.ValidateEvent(NewTextMessageStartEvent(messageID, WithRole("assistant"))))
+			require.NoError(t, v.ValidateEvent(NewTextMessageEndEvent(messageID)))
+			require.NoError(t, v.ValidateEvent(NewRunFinishedEvent("thread-1", runID)))
+			assert.Empty(t, v.messageRoles)
+		}
+	})
+
+	t.Run("roles outside runs are forgotten when the message closes", func(t *testing.T) {
+		v := NewValidator(DefaultValidationConfig())
+		for i := 0; i < 3; i++ {
+			messageID := fmt.Sprintf("msg-%d", i)
+			require.NoError(t, v.ValidateEvent(NewTextMessageStartEvent(messageID, WithRole("assistant"))))
+			assert.EqualError(t, v.ValidateEvent(NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID(messageID).WithChunkRole("user").WithChunkDelta("hi")),
+				fmt.Sprintf(`message %s has role "user" but was started with role "assistant"`, messageID))
+			require.NoError(t, v.ValidateEvent(NewTextMessageEndEvent(messageID)))
+			require.NoError(t, v.ValidateEvent(NewToolCallResultEvent(fmt.Sprintf("result-%d", i), "tool-1", "done")))
+			require.NoError(t, v.ValidateEvent(NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID(fmt.Sprintf("chunk-%d", i)).WithChunkRole("assistant").WithChunkDelta("hi")))
+			assert.Empty(t, v.messageRoles)
+		}
+	})
+}
+
+func TestValidateAllowedRoles(t *testing.T) {
+	config := ValidationConfig{AllowedRoles: []string{"assistant", "user", "tool"}}
+	run := NewRunStartedEvent("thread-1", "run-1")
+
+	t.Run("text message start", func(t *testing.T) {
+		assert.NoError(t, ValidateSequenceWithConfig([]Event{run, NewTextMessageStartEvent("msg-1", WithRole("assistant"))}, config))
+
+		err := ValidateSequenceWithConfig([]Event{run, NewTextMessageStartEvent("msg-1", WithRole("developer"))}, config)
+		assert.EqualError(t, err, `message msg-1 has disallowed role "developer"`)
+	})
+
+	t.Run("text message chunk", func(t *testing.T) {
+		chunk := NewTextMessageChunkEvent(nil, nil, nil).WithChunkMessageID("msg-1").WithChunkRole("system").WithChunkDelta("hi")
+		assert.EqualError(t, ValidateSequenceWithConfig([]Event{run, chunk}, config), `message msg-1 has disallowed role "system"`)
+	})
+
+	t.Run("messages snapshot", func(t *testing.T) {
+		snapshot := NewMessagesSnapshotEvent([]Message{
+			{ID: "msg-1", Role: coretypes.RoleUser, Content: "hello"},
+			{ID: "msg-2", Role: coretypes.RoleDeveloper, Content: "be brief"},
+		})
+		assert.EqualError(t, ValidateSequenceWithConfig([]Event{snapshot}, config), `message msg-2 has disallowed role "developer"`)
+		assert.NoError(t, ValidateSequence([]Event{snapshot}))
+	})
+}
+
+func TestValidateIDFormat(t *testing.T) {
+	config := ValidationConfig{ValidateIDFormat: true}
+
+	t.Run("well formed IDs", func(t *testing.T) {
+		assert.NoError(t, ValidateSequenceWithConfig(toolCallSequence("tool-1", `{"q":1}`), config))
+	})
+
+	t.Run("whitespace", func(t *testing.T) {
+		err := NewValidator(config).ValidateEvent(NewRunStartedEvent("thread-1", " run-1"))
+		assert.EqualError(t, err, `event 0 validation failed: runId " run-1" has leading or trailing whitespace`)
+	})
+
+	t.Run("control characters", func(t *testing.T) {
+		v := NewValidator(config)
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+		err := v.ValidateEvent(NewToolCallStartEvent("tool-1", "search", WithParentMessageID("msg\x001")))
+		assert.EqualError(t, err, `event 1 validation failed: parentMessageId "msg\x001" contains control characters`)
+	})
+
+	t.Run("max length", func(t *testing.T) {
+		config := ValidationConfig{ValidateIDFormat: true, MaxIDLength: 5}
+		err := NewValidator(config).ValidateEvent(NewRunStartedEvent("t-1", "run-123"))
+		assert.EqualError(t, err, `event 0 validation failed: runId "run-123" is longer than 5 bytes`)
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		config := ValidationConfig{ValidateIDFormat: true, IDPattern: regexp.MustCompile(`^[a-z]+-[0-9]+$`)}
+		assert.NoError(t, NewValidator(config).ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+
+		err := NewValidator(config).ValidateEvent(NewRunStartedEvent("thread-1", "RUN_1"))
+		assert.EqualError(t, err, `event 0 validation failed: runId "RUN_1" does not match pattern ^[a-z]+-[0-9]+$`)
+	})
+
+	t.Run("empty IDs are left to structural validation", func(t *testing.T) {
+		config := ValidationConfig{ValidateIDFormat: true, AllowEmptyIDs: true, IDPattern: regexp.MustCompile(`^run-`)}
+		assert.NoError(t, NewValidator(config).ValidateEvent(NewRunErrorEvent("failed")))
+	})
+
+	t.Run("messages snapshot", func(t *testing.T) {
+		snapshot := func(messageID, toolCallID string) Event {
+			return NewMessagesSnapshotEvent([]Message{
+				{ID: "msg-1", Role: coretypes.RoleAssistant, ToolCalls: []coretypes.ToolCall{
+					{ID: toolCallID, Type: "function", Function: coretypes.FunctionCall{Name: "search", Arguments: "{}"}},
+				}},
+				{ID: messageID, Role: coretypes.RoleTool, Content: "3 results", ToolCallID: toolCallID},
+			})
+		}
+		assert.NoError(t, NewValidator(config).ValidateEvent(snapshot("msg-2", "tool-1")))
+
+		err := NewValidator(config).ValidateEvent(snapshot("msg-2 ", "tool-1"))
+		assert.EqualError(t, err, `event 0 validation failed: messageId "msg-2 " has leading or trailing whitespace`)
+
+		err = NewValidator(config).ValidateEvent(snapshot("msg-2", "tool\x001"))
+		assert.EqualError(t, err, `event 0 validation failed: toolCallId "tool\x001" contains control characters`)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.NoError(t, NewValidator(DefaultValidationConfig()).ValidateEvent(NewRunStartedEvent("thread-1", " run-1 ")))
+	})
+
+	t.Run("production preset", func(t *testing.T) {
+		assert.Error(t, NewValidator(ProductionValidationConfig()).ValidateEvent(NewRunStartedEvent("thread-1", "run-1\n")))
+	})
+}
+
+func TestNormalizeIDs(t *testing.T) {
+	start := NewToolCallStartEvent(" tool-1\t", "search", WithParentMessageID("\nmsg-1 "))
+	assert.Same(t, start, NormalizeIDs(start))
+	assert.Equal(t, "tool-1", start.ToolCallID)
+	assert.Equal(t, "msg-1", *start.ParentMessageID)
+	assert.Equal(t, "search", start.ToolCallName)
+
+	run := NormalizeIDs(NewRunStartedEvent(" thread-1", "run-1 ")).(*RunStartedEvent)
+	assert.Equal(t, "thread-1", run.ThreadID())
+	assert.Equal(t, "run-1", run.RunID())
+
+	chunk := NewTextMessageChunkEvent(nil, nil, nil)
+	NormalizeIDs(chunk)
+	assert.Nil(t, chunk.MessageID)
+
+	assert.NoError(t, ValidateSequenceWithConfig([]Event{run}, ValidationConfig{ValidateIDFormat: true}))
+
+	snapshot := NormalizeIDs(NewMessagesSnapshotEvent([]Message{
+		{ID: " msg-1", Role: coretypes.RoleAssistant, ToolCalls: []coretypes.ToolCall{{ID: "tool-1\n", Type: "function"}}},
+		{ID: "msg-2 ", Role: coretypes.RoleTool, ToolCallID: "\ttool-1"},
+	})).(*MessagesSnapshotEvent)
+	assert.Equal(t, "msg-1", snapshot.Messages[0].ID)
+	assert.Equal(t, "tool-1", snapshot.Messages[0].ToolCalls[0].ID)
+	assert.Equal(t, "msg-2", snapshot.Messages[1].ID)
+	assert.Equal(t, "tool-1", snapshot.Messages[1].ToolCallID)
+}
+
+// expiringContext reports itself cancelled once Err has been called more than
+// checks times
+type expiringContext struct {
+	context.Context
+	checks int
+}
+
+func (c *expiringContext) Err() error {
+	if c.checks == 0 {
+		return context.DeadlineExceeded
+	}
+	c.checks--
+	return nil
+}
+
+func TestValidateSequenceContext(t *testing.T) {
+	seq := []Event{NewRunStartedEvent("thread-1", "run-1"), NewTextMessageStartEvent("msg-1", WithRole("assistant"))}
+	for i := 0; i < 1000; i++ {
+		seq = append(seq, NewTextMessageContentEvent("msg-1", "x"))
+	}
+	seq = append(seq, NewTextMessageEndEvent("msg-1"), NewRunFinishedEvent("thread-1", "run-1"))
+
+	t.Run("completes", func(t *testing.T) {
+		assert.NoError(t, ValidateSequenceContext(context.Background(), seq))
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx := &expiringContext{Context: context.Background(), checks: 2}
+		err := ValidateSequenceContext(ctx, seq)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.EqualError(t, err, "sequence validation stopped after 512 of 1004 events: context deadline exceeded")
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, ValidateSequenceContext(ctx, seq), context.Canceled)
+	})
+
+	t.Run("reports validation errors", func(t *testing.T) {
+		err := ValidateSequenceContextWithConfig(context.Background(), seq[1:], ValidationConfig{RequireActiveRun: true})
+		assert.EqualError(t, err, "event 0 validation failed: TEXT_MESSAGE_START event outside of an active run")
+	})
+}
+
+func TestCustomValidators(t *testing.T) {
+	noSteps := NewCustomValidator("no-steps", func(event Event) error {
+		if event.Type() == EventTypeStepStarted {
+			return errors.New("steps are not allowed")
+		}
+		return nil
+	})
+	panicking := NewCustomValidator("buggy", func(event Event) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	})
+
+	t.Run("errors are attributed to the validator", func(t *testing.T) {
+		config := ValidationConfig{CustomValidators: []CustomValidator{noSteps}}
+		v := NewValidator(config)
+		require.NoError(t, v.ValidateEvent(NewRunStartedEvent("thread-1", "run-1")))
+		assert.EqualError(t, v.ValidateEvent(NewStepStartedEvent("plan")), "event 1 validation failed: custom validator no-steps: steps are not allowed")
+	})
+
+	t.Run("panics become validation errors", func(t *testing.T) {
+		config := ValidationConfig{CustomValidators: []CustomValidator{panicking}}
+		err := NewValidator(config).ValidateEvent(NewRunStartedEvent("thread-1", "run-1"))
+		require.Error(t, err)
+
+		var validationErr *agerrors.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, RuleCustomValidatorPanic, validationErr.Rule)
+		assert.Equal(t, "buggy", validationErr.Details["validator"])
+		assert.Contains(t, err.Error(), "custom validator buggy panicked: assignment to entry in nil map")
+	})
+
+	t.Run("a panicking validator does not abort a batch", func(t *testing.T) {
+		report := ValidateBatch(context.Background(), [][]Event{
+			{NewRunStartedEvent("thread-1", "run-1")},
+			{NewRunStartedEvent("thread-1", "run-2")},
+		}, BatchOptions{Config: ValidationConfig{CustomValidators: []CustomValidator{panicking}}})
+
+		assert.Equal(t, 2, report.Invalid)
+		for _, issue := range report.Report.Errors {
+			assert.Contains(t, issue.Message, RuleCustomValidatorPanic)
+		}
+	})
+
+	t.Run("custom validators run after the protocol rules", func(t *testing.T) {
+		config := ValidationConfig{CustomValidators: []CustomValidator{panicking}}
+		err := NewValidator(config).ValidateEvent(NewRunFinishedEvent("thread-1", "run-1"))
+		assert.EqualError(t, err, "cannot finish run run-1 that was not started")
+	})
+}
@@ -17,14 +17,16 @@ type EventEncoder struct {
 	jsonCodec  encoding.Codec
 }
 
-// NewEventEncoder creates a new event encoder with content negotiation support
-func NewEventEncoder() *EventEncoder {
+// NewEventEncoder creates a new event encoder with content negotiation support.
+// The options are applied on top of the default encoding options and passed
+// to the underlying codec, for example to attach a SizeObserver.
+func NewEventEncoder(options ...encoding.EncodingOption) *EventEncoder {
 	// Create content negotiator with JSON as preferred type
 	negotiator := negotiation.NewContentNegotiator("application/json")
 
 	return &EventEncoder{
 		negotiator: negotiator,
-		jsonCodec:  json.NewCodec(),
+		jsonCodec:  json.NewJSONCodec(encoding.NewEncodingOptions(options...), encoding.NewDecodingOptions()),
 	}
 }
 
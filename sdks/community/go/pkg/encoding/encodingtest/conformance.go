@@ -0,0 +1,363 @@
+// Package encodingtest provides a conformance suite for AG-UI codecs. It
+// imports the testing package, so it is kept apart from package encoding and
+// is meant to be used only from tests.
+package encodingtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/types"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+)
+
+// ==============================================================================
+// CODEC CONFORMANCE SUITE
+// ==============================================================================
+
+// conformanceTimestamp is a fixed timestamp so round-trip comparisons are
+// deterministic
+const conformanceTimestamp int64 = 1700000000000
+
+// ConformanceCase is a single event exercised by RunConformance
+type ConformanceCase struct {
+	// Name identifies the case in subtest names
+	Name string
+
+	// Event is the event to round-trip
+	Event events.Event
+}
+
+// ConformanceCases returns the events exercised by RunConformance: one of
+// every event type with all optional fields populated, followed by edge cases
+// covering empty strings, unicode, large numbers and nested custom payloads.
+// A fresh set is built on every call, so callers may modify the events.
+func ConformanceCases() []ConformanceCase {
+	cases := []ConformanceCase{
+		// Lifecycle events
+		{"RunStarted", events.NewRunStartedEvent("thread-1", "run-1")},
+		{"RunFinished", events.NewRunFinishedEventWithOptions("thread-1", "run-1",
+			events.WithResult(map[string]any{"answer": "42", "tokens": float64(128)}),
+			events.WithSuccessOutcome(),
+		)},
+		{"RunFinishedInterrupt", events.NewRunFinishedEventWithOptions("thread-1", "run-1",
+			events.WithInterruptOutcome([]types.Interrupt{{
+				ID:             "int-1",
+				Reason:         "tool_call",
+				Message:        "approval required",
+				ToolCallID:     "tool-1",
+				ResponseSchema: map[string]any{"type": "object"},
+				ExpiresAt:      "2030-01-01T00:00:00Z",
+				Metadata:       map[string]any{"priority": "high"},
+			}}),
+		)},
+		{"RunError", events.NewRunErrorEvent("something failed", events.WithErrorCode("E_FAIL"), events.WithRunID("run-1"))},
+		{"RunErrorWithCauses", events.NewRunErrorEvent("run failed",
+			events.WithErrorCause("STEP_FAILED", "step plan failed"),
+			events.WithErrorCause("", "tool search timed out"),
+		)},
+		{"StepStarted", events.NewStepStartedEvent("plan")},
+		{"StepFinished", events.NewStepFinishedEvent("plan")},
+
+		// Text message events
+		{"TextMessageStart", events.NewTextMessageStartEvent("msg-1", events.WithRole("assistant"), events.WithName("helper"))},
+		{"TextMessageContent", events.NewTextMessageContentEvent("msg-1", "Hello")},
+		{"TextMessageEnd", events.NewTextMessageEndEvent("msg-1")},
+		{"TextMessageChunk", events.NewTextMessageChunkEvent(nil, nil, nil).
+			WithChunkMessageID("msg-1").WithChunkRole("assistant").WithChunkDelta("Hi").WithChunkName("helper")},
+
+		// Tool call events
+		{"ToolCallStart", events.NewToolCallStartEvent("tool-1", "search", events.WithParentMessageID("msg-1"))},
+		{"ToolCallArgs", events.NewToolCallArgsEvent("tool-1", `{"query":`)},
+		{"ToolCallEnd", events.NewToolCallEndEvent("tool-1")},
+		{"ToolCallChunk", events.NewToolCallChunkEvent().
+			WithToolCallChunkID("tool-1").WithToolCallChunkName("search").
+			WithToolCallChunkParentMessageID("msg-1").WithToolCallChunkDelta(`"ag-ui"}`)},
+		{"ToolCallResult", events.NewToolCallResultEvent("msg-2", "tool-1", `{"hits":3}`)},
+
+		// State events
+		{"StateSnapshot", events.NewStateSnapshotEvent(map[string]any{"counter": float64(1), "tags": []any{"a", "b"}})},
+		{"StateDelta", events.NewStateDeltaEvent([]events.JSONPatchOperation{
+			{Op: "add", Path: "/counter", Value: float64(2)},
+			{Op: "remove", Path: "/tags/0"},
+			{Op: "move", Path: "/moved", From: "/counter"},
+		})},
+		{"MessagesSnapshot", events.NewMessagesSnapshotEvent([]events.Message{
+			{ID: "msg-0", Role: types.RoleUser, Content: "What is AG-UI?"},
+			{ID: "msg-1", Role: types.RoleAssistant, Content: "A protocol.", ToolCalls: []events.ToolCall{{
+				ID:       "tool-1",
+				Type:     types.ToolCallTypeFunction,
+				Function: events.Function{Name: "search", Arguments: `{"query":"ag-ui"}`},
+			}}},
+			{ID: "msg-2", Role: types.RoleTool, Content: `{"hits":3}`, ToolCallID: "tool-1"},
+		})},
+
+		// Activity events
+		{"ActivitySnapshot", events.NewActivitySnapshotEvent("act-1", "progress", map[string]any{"percent": float64(50)})},
+		{"ActivityDelta", events.NewActivityDeltaEvent("act-1", "progress", []events.JSONPatchOperation{
+			{Op: "replace", Path: "/percent", Value: float64(75)},
+		})},
+
+		// Reasoning events
+		{"ReasoningStart", events.NewReasoningStartEvent("reason-1")},
+		{"ReasoningMessageStart", events.NewReasoningMessageStartEvent("reason-msg-1", "assistant")},
+		{"ReasoningMessageContent", events.NewReasoningMessageContentEvent("reason-msg-1", "Thinking...")},
+		{"ReasoningMessageEnd", events.NewReasoningMessageEndEvent("reason-msg-1")},
+		{"ReasoningMessageChunk", events.NewReasoningMessageChunkEvent(nil, nil).
+			WithChunkMessageID("reason-msg-1").WithChunkDelta("more")},
+		{"ReasoningEncryptedValue", events.NewReasoningEncryptedValueEvent(events.ReasoningEncryptedValueSubtypeMessage, "msg-1", "ZW5jcnlwdGVk")},
+		{"ReasoningEnd", events.NewReasoningEndEvent("reason-1")},
+
+		// Deprecated thinking events
+		{"ThinkingStart", events.NewThinkingStartEvent().WithTitle("Planning")},
+		{"ThinkingTextMessageStart", events.NewThinkingTextMessageStartEvent()},
+		{"ThinkingTextMessageContent", events.NewThinkingTextMessageContentEvent("hmm")},
+		{"ThinkingTextMessageEnd", events.NewThinkingTextMessageEndEvent()},
+		{"ThinkingEnd", events.NewThinkingEndEvent()},
+
+		// Special events
+		{"Raw", events.NewRawEvent(map[string]any{"provider": "openai", "id": "chunk-1"}, events.WithSource("openai"))},
+		{"Custom", events.NewCustomEvent("analytics", events.WithValue(map[string]any{"clicks": float64(3)}))},
+
+		// Edge cases
+		{"EmptyStrings", events.NewCustomEvent("empty", events.WithValue(map[string]any{
+			"":      "",
+			"empty": "",
+			"list":  []any{"", ""},
+		}))},
+		{"EmptyMessageContent", events.NewMessagesSnapshotEvent([]events.Message{
+			{ID: "msg-1", Role: types.RoleAssistant, Content: ""},
+		})},
+		{"EmptyMessagesSnapshot", events.NewMessagesSnapshotEvent([]events.Message{})},
+		{"Unicode", events.NewTextMessageContentEvent("msg-ünï", "héllo 世界 🚀    \"quoted\" \\ back\\slash\n\ttab <&>")},
+		{"UnicodeCustom", events.NewCustomEvent("名前", events.WithValue(map[string]any{"emoji": "👋🏽", "rtl": "مرحبا"}))},
+		{"LargeNumbers", events.NewStateSnapshotEvent(map[string]any{
+			"maxSafeInt": float64(1 << 53),
+			"minSafeInt": float64(-(1 << 53)),
+			"huge":       1e300,
+			"tiny":       math.SmallestNonzeroFloat64,
+			"zero":       float64(0),
+			"fraction":   0.1,
+		})},
+		{"NestedCustom", events.NewCustomEvent("nested", events.WithValue(map[string]any{
+			"level1": map[string]any{
+				"level2": map[string]any{
+					"level3": []any{
+						map[string]any{"ok": true, "nothing": nil},
+						[]any{float64(1), "two", false, []any{}},
+					},
+				},
+				"empty": map[string]any{},
+			},
+		}))},
+		{"RawEventPassthrough", withRawEvent(events.NewTextMessageEndEvent("msg-1"), map[string]any{"upstream": map[string]any{"id": "x"}})},
+
+		// Optional base and custom fields
+		{"TraceContext", withTrace(events.NewToolCallStartEvent("tool-1", "search"), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")},
+		{"Sequence", events.WithSequence(7, events.NewTextMessageContentEvent("msg-1", "numbered"))},
+		{"MaxSequence", events.WithSequence(math.MaxUint64, events.NewStepFinishedEvent("plan"))},
+		{"CustomSchema", events.NewCustomEvent("metrics", events.WithValue(map[string]any{"latency": float64(12)})).
+			WithSchemaURI("https://example.com/schemas/metrics.json")},
+	}
+
+	for _, c := range cases {
+		c.Event.SetTimestamp(conformanceTimestamp)
+	}
+
+	maxTimestamp := events.NewStepStartedEvent("max-timestamp")
+	maxTimestamp.SetTimestamp(math.MaxInt64)
+	cases = append(cases, ConformanceCase{"MaxTimestamp", maxTimestamp})
+
+	return cases
+}
+
+func withRawEvent(event events.Event, raw any) events.Event {
+	event.GetBaseEvent().RawEvent = raw
+	return event
+}
+
+func withTrace(event events.Event, traceID, spanID string) events.Event {
+	base := event.GetBaseEvent()
+	base.TraceID = traceID
+	base.SpanID = spanID
+	return event
+}
+
+// NonFiniteCases returns events whose payloads contain NaN or infinite
+// floats, which every codec must reject with events.ErrNonFiniteNumber
+func NonFiniteCases() []ConformanceCase {
+	return []ConformanceCase{
+		{"StateSnapshotNaN", events.NewStateSnapshotEvent(map[string]any{"score": math.NaN()})},
+		{"StateSnapshotNestedInf", events.NewStateSnapshotEvent(map[string]any{"stats": []any{1.5, math.Inf(1)}})},
+		{"CustomNegativeInf", events.NewCustomEvent("metrics", events.WithValue(map[string]any{"min": math.Inf(-1)}))},
+		{"CustomFloat32NaN", events.NewCustomEvent("metrics", events.WithValue(float32(math.NaN())))},
+	}
+}
+
+// RunConformance checks that enc and dec round-trip every event in
+// ConformanceCases, individually and as a batch through EncodeMultiple and
+// DecodeMultiple, and that enc rejects every event in NonFiniteCases. Two
+// events are considered equal when events.Equal reports them equal with
+// timestamps compared.
+//
+// It is intended for codec authors, including third-party implementations:
+//
+//	func TestMyCodecConformance(t *testing.T) {
+//		encodingtest.RunConformance(t, mycodec.NewEncoder(), mycodec.NewDecoder())
+//	}
+func RunConformance(t *testing.T, enc encoding.Encoder, dec encoding.Decoder) {
+	t.Helper()
+
+	ctx := context.Background()
+	cases := ConformanceCases()
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			data, err := enc.Encode(ctx, c.Event)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			decoded, err := dec.Decode(ctx, data)
+			if err != nil {
+				t.Fatalf("Decode() error = %v\nencoded: %s", err, data)
+			}
+
+			if err := conformanceEqual(c.Event, decoded); err != nil {
+				t.Errorf("round-trip mismatch: %v\nencoded: %s", err, data)
+			}
+		})
+	}
+
+	t.Run("EncodeMultiple", func(t *testing.T) {
+		batch := make([]events.Event, len(cases))
+		for i, c := range cases {
+			batch[i] = c.Event
+		}
+
+		data, err := enc.EncodeMultiple(ctx, batch)
+		if err != nil {
+			t.Fatalf("EncodeMultiple() error = %v", err)
+		}
+
+		decoded, err := dec.DecodeMultiple(ctx, data)
+		if err != nil {
+			t.Fatalf("DecodeMultiple() error = %v", err)
+		}
+
+		if len(decoded) != len(batch) {
+			t.Fatalf("DecodeMultiple() returned %d events, want %d", len(decoded), len(batch))
+		}
+
+		for i := range batch {
+			if err := conformanceEqual(batch[i], decoded[i]); err != nil {
+				t.Errorf("event %d (%s): round-trip mismatch: %v", i, cases[i].Name, err)
+			}
+		}
+	})
+
+	t.Run("EncodeMultipleEmpty", func(t *testing.T) {
+		data, err := enc.EncodeMultiple(ctx, []events.Event{})
+		if err != nil {
+			t.Fatalf("EncodeMultiple() error = %v", err)
+		}
+
+		decoded, err := dec.DecodeMultiple(ctx, data)
+		if err != nil {
+			t.Fatalf("DecodeMultiple() error = %v", err)
+		}
+
+		if len(decoded) != 0 {
+			t.Errorf("DecodeMultiple() returned %d events, want 0", len(decoded))
+		}
+	})
+
+	for _, c := range NonFiniteCases() {
+		t.Run(c.Name, func(t *testing.T) {
+			if _, err := enc.Encode(ctx, c.Event); !errors.Is(err, events.ErrNonFiniteNumber) {
+				t.Errorf("Encode() error = %v, want ErrNonFiniteNumber", err)
+			}
+
+			batch := []events.Event{events.NewStepStartedEvent("step"), c.Event}
+			if _, err := enc.EncodeMultiple(ctx, batch); !errors.Is(err, events.ErrNonFiniteNumber) {
+				t.Errorf("EncodeMultiple() error = %v, want ErrNonFiniteNumber", err)
+			}
+		})
+	}
+
+	t.Run("ContentType", func(t *testing.T) {
+		if enc.ContentType() != dec.ContentType() {
+			t.Errorf("encoder content type %q does not match decoder content type %q", enc.ContentType(), dec.ContentType())
+		}
+	})
+}
+
+// FuzzConformance fuzzes the round trip through dec and enc, seeding the
+// corpus with the encoded ConformanceCases. Inputs dec rejects are ignored;
+// every event it accepts must encode again and decode back to an equal
+// event.
+//
+//	func FuzzMyCodec(f *testing.F) {
+//		encodingtest.FuzzConformance(f, mycodec.NewEncoder(), mycodec.NewDecoder())
+//	}
+func FuzzConformance(f *testing.F, enc encoding.Encoder, dec encoding.Decoder) {
+	ctx := context.Background()
+	for _, c := range ConformanceCases() {
+		data, err := enc.Encode(ctx, c.Event)
+		if err != nil {
+			f.Fatalf("%s: Encode() error = %v", c.Name, err)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		event, err := dec.Decode(ctx, data)
+		if err != nil {
+			return
+		}
+
+		encoded, err := enc.Encode(ctx, event)
+		if err != nil {
+			t.Fatalf("Encode() of a decoded %s event error = %v\ninput: %s", event.Type(), err, data)
+		}
+
+		decoded, err := dec.Decode(ctx, encoded)
+		if err != nil {
+			t.Fatalf("Decode() of a re-encoded %s event error = %v\nencoded: %s", event.Type(), err, encoded)
+		}
+
+		if err := conformanceEqual(event, decoded); err != nil {
+			t.Errorf("round-trip mismatch: %v\nencoded: %s", err, encoded)
+		}
+	})
+}
+
+// conformanceEqual reports how want and got differ, or nil if they are equal
+func conformanceEqual(want, got events.Event) error {
+	if got == nil {
+		return fmt.Errorf("decoded event is nil")
+	}
+
+	if reflect.TypeOf(want) != reflect.TypeOf(got) {
+		return fmt.Errorf("type = %T, want %T", got, want)
+	}
+
+	if events.Equal(want, got, events.WithTimestamps()) {
+		return nil
+	}
+
+	wantJSON, err := want.ToJSON()
+	if err != nil {
+		return fmt.Errorf("serializing original event: %w", err)
+	}
+	gotJSON, err := got.ToJSON()
+	if err != nil {
+		return fmt.Errorf("serializing decoded event: %w", err)
+	}
+	return fmt.Errorf("\n got: %s\nwant: %s", gotJSON, wantJSON)
+}
@@ -1,6 +1,7 @@
 package encoding
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 )
@@ -162,6 +163,74 @@ func (e *RegistryError) WithContext(key string, value interface{}) *RegistryErro
 	return e
 }
 
+// Sentinel errors classifying why an event could not be decoded. They are
+// reported through DecodeError.Kind and match with errors.Is.
+var (
+	// ErrUnknownEventType indicates the input names an event type this SDK does not know
+	ErrUnknownEventType = errors.New("unknown event type")
+
+	// ErrTruncatedInput indicates the input ended before a complete event was read
+	ErrTruncatedInput = errors.New("truncated input")
+
+	// ErrMalformedInput indicates the input is not well-formed for the format
+	ErrMalformedInput = errors.New("malformed input")
+
+	// ErrFieldTypeMismatch indicates a field holds a value of the wrong type
+	ErrFieldTypeMismatch = errors.New("field type mismatch")
+
+	// ErrUnknownField indicates a field not defined for the event type was rejected by strict decoding
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// ErrEventTooLarge is returned when a single event exceeds the configured
+// MaxEventBytes. Decoders report it before parsing the event.
+var ErrEventTooLarge = errors.New("event too large")
+
+// ErrEventSkipped is returned by Decode for an event of an unknown type when
+// the decoder uses UnknownTypeSkip. Batch decoders drop such events instead
+// of failing.
+var ErrEventSkipped = errors.New("event skipped")
+
+// DecodeError describes precisely where and why decoding of an event failed.
+// Format implementations wrap it inside a DecodingError, so callers should
+// use errors.As to retrieve it and errors.Is against the Err* sentinels to
+// branch on the failure kind.
+type DecodeError struct {
+	Offset int    // Byte offset within the event where the failure was detected, or -1 if unknown
+	Field  string // Dotted path of the offending field, if known
+	Kind   error  // Sentinel classifying the failure (e.g. ErrTruncatedInput)
+	Cause  error  // The underlying error reported by the format implementation
+}
+
+func (e *DecodeError) Error() string {
+	msg := "decode failed"
+	switch {
+	case e.Cause != nil:
+		msg = e.Cause.Error()
+	case e.Kind != nil:
+		msg = e.Kind.Error()
+	}
+	if e.Field != "" {
+		msg = fmt.Sprintf("%s (field %q)", msg, e.Field)
+	}
+	if e.Offset >= 0 {
+		msg = fmt.Sprintf("%s at offset %d", msg, e.Offset)
+	}
+	return msg
+}
+
+// Unwrap exposes both the failure kind and the underlying cause to errors.Is/As
+func (e *DecodeError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
 // ==============================================================================
 // ERROR CONSTRUCTORS
 // ==============================================================================
@@ -267,6 +336,12 @@ func IsResourceError(err error) bool {
 	return ok
 }
 
+// IsDecodeError checks if an error is, or wraps, a DecodeError
+func IsDecodeError(err error) bool {
+	var decodeErr *DecodeError
+	return errors.As(err, &decodeErr)
+}
+
 // IsRegistryError checks if an error is a RegistryError
 func IsRegistryError(err error) bool {
 	_, ok := err.(*RegistryError)
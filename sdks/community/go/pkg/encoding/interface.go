@@ -203,6 +203,41 @@ type EncodingOptions struct {
 
 	// CrossSDKCompatibility ensures compatibility with other SDKs
 	CrossSDKCompatibility bool
+
+	// SizeObserver, when set, is called with the type and serialized size
+	// of every successfully encoded event
+	SizeObserver SizeObserver
+}
+
+// SizeObserver receives the serialized size in bytes of an encoded event,
+// for example to feed a size histogram. It is called synchronously on the
+// encoding goroutine, so it must be cheap and safe for concurrent use.
+type SizeObserver func(eventType events.EventType, bytes int)
+
+// EncodingOption configures EncodingOptions
+type EncodingOption func(*EncodingOptions)
+
+// NewEncodingOptions returns the default encoding options (cross-SDK
+// compatible, with output validation) with the given options applied
+func NewEncodingOptions(options ...EncodingOption) *EncodingOptions {
+	opts := &EncodingOptions{
+		CrossSDKCompatibility: true,
+		ValidateOutput:        true,
+	}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return opts
+}
+
+// WithSizeObserver reports the serialized size of each encoded event to
+// observer
+func WithSizeObserver(observer SizeObserver) EncodingOption {
+	return func(opts *EncodingOptions) {
+		opts.SizeObserver = observer
+	}
 }
 
 // Validate validates the encoding options
@@ -239,6 +274,11 @@ func (opts *EncodingOptions) Validate() error {
 	return nil
 }
 
+// DefaultMaxEventBytes is the per-event size limit applied by the default
+// decoding options. It is generous enough for large state snapshots while
+// still bounding the memory a single hostile event can claim.
+const DefaultMaxEventBytes = 16 << 20 // 16 MiB
+
 // DecodingOptions provides options for decoding operations
 type DecodingOptions struct {
 	// Strict enables strict validation during decoding
@@ -247,6 +287,11 @@ type DecodingOptions struct {
 	// MaxSize specifies maximum input size to process (0 for unlimited)
 	MaxSize int64
 
+	// MaxEventBytes specifies the maximum size of a single event (0 for
+	// unlimited). Larger events are rejected with ErrEventTooLarge before
+	// they are parsed.
+	MaxEventBytes int64
+
 	// BufferSize specifies buffer size for streaming operations
 	BufferSize int
 
@@ -255,6 +300,78 @@ type DecodingOptions struct {
 
 	// ValidateEvents enables event validation after decoding
 	ValidateEvents bool
+
+	// UseNumber decodes numbers inside free-form payloads (custom event
+	// values, raw events, state snapshots and deltas, run results) as
+	// json.Number instead of float64. This preserves integers beyond 2^53
+	// exactly, at the cost of consumers having to convert json.Number
+	// themselves rather than type-asserting float64. Typed fields such as
+	// timestamps are unaffected.
+	UseNumber bool
+
+	// UnknownTypePolicy controls what happens to events whose type this SDK
+	// does not recognize. The zero value rejects them.
+	UnknownTypePolicy UnknownTypePolicy
+}
+
+// UnknownTypePolicy selects how a decoder handles event types it does not
+// recognize, such as those introduced by a newer protocol version
+type UnknownTypePolicy int
+
+const (
+	// UnknownTypeError fails decoding with ErrUnknownEventType
+	UnknownTypeError UnknownTypePolicy = iota
+	// UnknownTypePreserveAsRaw decodes the event as an events.RawEvent whose
+	// Event holds the original bytes as a json.RawMessage and whose Source
+	// holds the unrecognized type. Encoding that RawEvent produces a RAW
+	// event wrapping the original payload, not the payload itself; proxies
+	// that must forward the event unchanged should write Event directly
+	UnknownTypePreserveAsRaw
+	// UnknownTypeSkip drops the event: Decode returns ErrEventSkipped and
+	// batch decoders leave it out of the result
+	UnknownTypeSkip
+)
+
+// DecodingOption configures DecodingOptions
+type DecodingOption func(*DecodingOptions)
+
+// NewDecodingOptions returns the default decoding options (strict, with event
+// validation) with the given options applied
+func NewDecodingOptions(options ...DecodingOption) *DecodingOptions {
+	opts := &DecodingOptions{
+		Strict:         true,
+		ValidateEvents: true,
+		MaxEventBytes:  DefaultMaxEventBytes,
+	}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	return opts
+}
+
+// WithUseNumber decodes numbers in free-form payloads as json.Number so that
+// large integer IDs survive decoding without losing precision
+func WithUseNumber() DecodingOption {
+	return func(opts *DecodingOptions) {
+		opts.UseNumber = true
+	}
+}
+
+// WithMaxEventBytes limits the size of a single event. Zero removes the
+// limit.
+func WithMaxEventBytes(n int64) DecodingOption {
+	return func(opts *DecodingOptions) {
+		opts.MaxEventBytes = n
+	}
+}
+
+// WithUnknownTypePolicy sets how events of unknown types are handled
+func WithUnknownTypePolicy(policy UnknownTypePolicy) DecodingOption {
+	return func(opts *DecodingOptions) {
+		opts.UnknownTypePolicy = policy
+	}
 }
 
 // Validate validates the decoding options
@@ -273,6 +390,16 @@ func (opts *DecodingOptions) Validate() error {
 		return fmt.Errorf("max size cannot be negative, got %d", opts.MaxSize)
 	}
 
+	// Validate max event size
+	if opts.MaxEventBytes < 0 {
+		return fmt.Errorf("max event bytes cannot be negative, got %d", opts.MaxEventBytes)
+	}
+
+	// Validate unknown type policy
+	if opts.UnknownTypePolicy < UnknownTypeError || opts.UnknownTypePolicy > UnknownTypeSkip {
+		return fmt.Errorf("unknown type policy %d is not supported", opts.UnknownTypePolicy)
+	}
+
 	return nil
 }
 
@@ -32,16 +32,7 @@ func NewJSONCodec(encOptions *encoding.EncodingOptions, decOptions *encoding.Dec
 
 // NewDefaultJSONCodec creates a new JSON codec with default options
 func NewDefaultJSONCodec() *JSONCodec {
-	return NewJSONCodec(
-		&encoding.EncodingOptions{
-			CrossSDKCompatibility: true,
-			ValidateOutput:        true,
-		},
-		&encoding.DecodingOptions{
-			Strict:         true,
-			ValidateEvents: true,
-		},
-	)
+	return NewJSONCodec(encoding.NewEncodingOptions(), encoding.NewDecodingOptions())
 }
 
 // Encode delegates to the encoder
@@ -88,20 +79,13 @@ type CodecOptions struct {
 
 // DefaultCodecOptions returns default codec options
 func DefaultCodecOptions() *CodecOptions {
-	return &CodecOptions{
-		EncodingOptions: &encoding.EncodingOptions{
-			CrossSDKCompatibility: true,
-			ValidateOutput:        true,
-			Pretty:                false,
-			BufferSize:            4096,
-		},
-		DecodingOptions: &encoding.DecodingOptions{
-			Strict:             true,
-			ValidateEvents:     true,
-			AllowUnknownFields: false,
-			BufferSize:         4096,
-		},
+	opts := &CodecOptions{
+		EncodingOptions: encoding.NewEncodingOptions(),
+		DecodingOptions: encoding.NewDecodingOptions(),
 	}
+	opts.EncodingOptions.BufferSize = 4096
+	opts.DecodingOptions.BufferSize = 4096
+	return opts
 }
 
 // PrettyCodecOptions returns codec options for pretty-printed JSON
@@ -124,6 +108,7 @@ func CompatibilityCodecOptions() *CodecOptions {
 			Strict:             false,
 			ValidateEvents:     true,
 			AllowUnknownFields: true,
+			MaxEventBytes:      encoding.DefaultMaxEventBytes,
 			BufferSize:         8192,
 		},
 	}
@@ -142,6 +127,7 @@ func StreamingCodecOptions() *CodecOptions {
 			Strict:             false,
 			ValidateEvents:     false, // Skip validation for performance
 			AllowUnknownFields: true,
+			MaxEventBytes:      encoding.DefaultMaxEventBytes,
 			BufferSize:         16384, // Larger buffer for streaming
 		},
 	}
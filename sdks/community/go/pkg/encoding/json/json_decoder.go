@@ -1,9 +1,13 @@
 package json
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"sync/atomic"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
@@ -28,10 +32,7 @@ type JSONDecoder struct {
 // NewJSONDecoder creates a new JSON decoder with the given options
 func NewJSONDecoder(options *encoding.DecodingOptions) *JSONDecoder {
 	if options == nil {
-		options = &encoding.DecodingOptions{
-			Strict:         true,
-			ValidateEvents: true,
-		}
+		options = encoding.NewDecodingOptions()
 	}
 	return &JSONDecoder{
 		options:       options,
@@ -42,10 +43,7 @@ func NewJSONDecoder(options *encoding.DecodingOptions) *JSONDecoder {
 // NewJSONDecoderWithConcurrencyLimit creates a new JSON decoder with specified concurrency limit
 func NewJSONDecoderWithConcurrencyLimit(options *encoding.DecodingOptions, maxConcurrent int32) *JSONDecoder {
 	if options == nil {
-		options = &encoding.DecodingOptions{
-			Strict:         true,
-			ValidateEvents: true,
-		}
+		options = encoding.NewDecodingOptions()
 	}
 	return &JSONDecoder{
 		options:       options,
@@ -95,6 +93,13 @@ func (d *JSONDecoder) Decode(ctx context.Context, data []byte) (events.Event, er
 	}
 
 	// Check size limits
+	if d.options.MaxEventBytes > 0 && int64(len(data)) > d.options.MaxEventBytes {
+		return nil, &encoding.DecodingError{
+			Format:  "json",
+			Message: fmt.Sprintf("event of %d bytes exceeds max event size of %d bytes", len(data), d.options.MaxEventBytes),
+			Cause:   encoding.ErrEventTooLarge,
+		}
+	}
 	if d.options.MaxSize > 0 && int64(len(data)) > d.options.MaxSize {
 		return nil, &encoding.DecodingError{
 			Format:  "json",
@@ -110,7 +115,7 @@ func (d *JSONDecoder) Decode(ctx context.Context, data []byte) (events.Event, er
 			Format:  "json",
 			Data:    data,
 			Message: "failed to decode event type",
-			Cause:   err,
+			Cause:   newDecodeError(err),
 		}
 	}
 
@@ -187,7 +192,7 @@ func (d *JSONDecoder) DecodeMultiple(ctx context.Context, data []byte) ([]events
 			Format:  "json",
 			Data:    data,
 			Message: "failed to decode event array",
-			Cause:   err,
+			Cause:   newDecodeError(err),
 		}
 	}
 
@@ -195,6 +200,9 @@ func (d *JSONDecoder) DecodeMultiple(ctx context.Context, data []byte) ([]events
 	events := make([]events.Event, 0, len(rawEvents))
 	for i, rawEvent := range rawEvents {
 		event, err := d.Decode(ctx, rawEvent)
+		if errors.Is(err, encoding.ErrEventSkipped) {
+			continue
+		}
 		if err != nil {
 			// Enhance error with index information
 			if decErr, ok := err.(*encoding.DecodingError); ok {
@@ -208,6 +216,47 @@ func (d *JSONDecoder) DecodeMultiple(ctx context.Context, data []byte) ([]events
 	return events, nil
 }
 
+// DecodeBatch decodes a batch of events given either as a JSON array or as
+// newline-delimited JSON (one event per line, blank lines ignored). The
+// format is detected from the first non-whitespace byte, so request bodies
+// can use either without a separate content type.
+func (d *JSONDecoder) DecodeBatch(ctx context.Context, data []byte) ([]events.Event, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return d.DecodeMultiple(ctx, trimmed)
+	}
+
+	if d.options.MaxSize > 0 && int64(len(data)) > d.options.MaxSize {
+		return nil, &encoding.DecodingError{
+			Format:  "json",
+			Data:    data,
+			Message: fmt.Sprintf("data exceeds max size of %d bytes", d.options.MaxSize),
+		}
+	}
+
+	decoded := make([]events.Event, 0, bytes.Count(trimmed, []byte{'\n'})+1)
+	for i, line := range bytes.Split(trimmed, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		event, err := d.Decode(ctx, line)
+		if errors.Is(err, encoding.ErrEventSkipped) {
+			continue
+		}
+		if err != nil {
+			if decErr, ok := err.(*encoding.DecodingError); ok {
+				decErr.Message = fmt.Sprintf("failed to decode event on line %d: %s", i+1, decErr.Message)
+			}
+			return nil, err
+		}
+		decoded = append(decoded, event)
+	}
+
+	return decoded, nil
+}
+
 // createEvent creates the appropriate event type based on the type string
 func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (events.Event, error) {
 	// Use buffer pooling for creating a byte reader
@@ -227,6 +276,9 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 	if d.options.Strict && !d.options.AllowUnknownFields {
 		decoder.DisallowUnknownFields()
 	}
+	if d.options.UseNumber {
+		decoder.UseNumber()
+	}
 
 	var err error
 	var event events.Event
@@ -312,6 +364,9 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 	case events.EventTypeCustom:
 		var e events.CustomEvent
 		err = decoder.Decode(&e)
+		if err == nil {
+			err = events.DecodeCustomEventValue(&e)
+		}
 		if err == nil {
 			event = &e
 		}
@@ -351,11 +406,139 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 			event = &e
 		}
 
+	case events.EventTypeToolCallChunk:
+		var e events.ToolCallChunkEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeToolCallResult:
+		var e events.ToolCallResultEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeActivitySnapshot:
+		var e events.ActivitySnapshotEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeActivityDelta:
+		var e events.ActivityDeltaEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeThinkingStart:
+		var e events.ThinkingStartEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeThinkingEnd:
+		var e events.ThinkingEndEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeThinkingTextMessageStart:
+		var e events.ThinkingTextMessageStartEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeThinkingTextMessageContent:
+		var e events.ThinkingTextMessageContentEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeThinkingTextMessageEnd:
+		var e events.ThinkingTextMessageEndEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningStart:
+		var e events.ReasoningStartEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningMessageStart:
+		var e events.ReasoningMessageStartEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningMessageContent:
+		var e events.ReasoningMessageContentEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningMessageEnd:
+		var e events.ReasoningMessageEndEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningMessageChunk:
+		var e events.ReasoningMessageChunkEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningEnd:
+		var e events.ReasoningEndEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
+	case events.EventTypeReasoningEncryptedValue:
+		var e events.ReasoningEncryptedValueEvent
+		err = decoder.Decode(&e)
+		if err == nil {
+			event = &e
+		}
+
 	default:
+		switch d.options.UnknownTypePolicy {
+		case encoding.UnknownTypePreserveAsRaw:
+			return events.NewRawEvent(json.RawMessage(bytes.Clone(data)), events.WithSource(string(eventType))), nil
+		case encoding.UnknownTypeSkip:
+			return nil, &encoding.DecodingError{
+				Format:  "json",
+				Data:    data,
+				Message: fmt.Sprintf("unknown event type: %s", eventType),
+				Cause:   encoding.ErrEventSkipped,
+			}
+		}
 		return nil, &encoding.DecodingError{
 			Format:  "json",
 			Data:    data,
 			Message: fmt.Sprintf("unknown event type: %s", eventType),
+			Cause: &encoding.DecodeError{
+				Offset: -1,
+				Field:  "type",
+				Kind:   encoding.ErrUnknownEventType,
+			},
 		}
 	}
 
@@ -364,7 +547,7 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 			Format:  "json",
 			Data:    data,
 			Message: fmt.Sprintf("failed to decode %s event", eventType),
-			Cause:   err,
+			Cause:   newDecodeError(err),
 		}
 	}
 
@@ -377,6 +560,39 @@ func (d *JSONDecoder) createEvent(eventType events.EventType, data []byte) (even
 	return event, nil
 }
 
+// newDecodeError classifies an error returned by encoding/json into a
+// structured DecodeError carrying the offset and field where available
+func newDecodeError(err error) *encoding.DecodeError {
+	decodeErr := &encoding.DecodeError{
+		Offset: -1,
+		Kind:   encoding.ErrMalformedInput,
+		Cause:  err,
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		decodeErr.Kind = encoding.ErrTruncatedInput
+	case errors.As(err, &syntaxErr):
+		decodeErr.Offset = int(syntaxErr.Offset)
+		// encoding/json reports truncation as a syntax error with a fixed message
+		if syntaxErr.Error() == "unexpected end of JSON input" {
+			decodeErr.Kind = encoding.ErrTruncatedInput
+		}
+	case errors.As(err, &typeErr):
+		decodeErr.Offset = int(typeErr.Offset)
+		decodeErr.Field = typeErr.Field
+		decodeErr.Kind = encoding.ErrFieldTypeMismatch
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// DisallowUnknownFields reports an unexported error type, so match on its message
+		decodeErr.Field = strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		decodeErr.Kind = encoding.ErrUnknownField
+	}
+
+	return decodeErr
+}
+
 // ContentType returns the MIME type this decoder handles
 func (d *JSONDecoder) ContentType() string {
 	return "application/json"
@@ -395,10 +611,7 @@ func (d *JSONDecoder) SupportsStreaming() bool {
 // Reset resets the decoder with new options (for pooling)
 func (d *JSONDecoder) Reset(options *encoding.DecodingOptions) {
 	if options == nil {
-		options = &encoding.DecodingOptions{
-			Strict:         true,
-			ValidateEvents: true,
-		}
+		options = encoding.NewDecodingOptions()
 	}
 	d.options = options
 }
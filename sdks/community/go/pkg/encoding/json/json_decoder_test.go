@@ -0,0 +1,334 @@
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/encodingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDecoderUseNumber(t *testing.T) {
+	// 2^53 + 1 cannot be represented exactly as a float64.
+	const largeID = "9007199254740993"
+	data := []byte(`{"type":"CUSTOM","name":"analytics","value":{"accountId":` + largeID + `,"ratio":0.5}}`)
+
+	t.Run("default decodes as float64", func(t *testing.T) {
+		decoder := NewJSONDecoder(nil)
+
+		event, err := decoder.Decode(context.Background(), data)
+		require.NoError(t, err)
+
+		value := event.(*events.CustomEvent).Value.(map[string]any)
+		accountID, ok := value["accountId"].(float64)
+		require.True(t, ok)
+		assert.NotEqual(t, largeID, strconv.FormatFloat(accountID, 'f', -1, 64))
+	})
+
+	t.Run("WithUseNumber preserves integer precision", func(t *testing.T) {
+		decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUseNumber()))
+
+		event, err := decoder.Decode(context.Background(), data)
+		require.NoError(t, err)
+
+		value := event.(*events.CustomEvent).Value.(map[string]any)
+		accountID, ok := value["accountId"].(json.Number)
+		require.True(t, ok)
+		assert.Equal(t, largeID, accountID.String())
+
+		asInt, err := accountID.Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(9007199254740993), asInt)
+
+		ratio, ok := value["ratio"].(json.Number)
+		require.True(t, ok)
+		asFloat, err := ratio.Float64()
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, asFloat)
+	})
+
+	t.Run("WithUseNumber applies to state snapshots", func(t *testing.T) {
+		decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUseNumber()))
+
+		event, err := decoder.Decode(context.Background(), []byte(`{"type":"STATE_SNAPSHOT","snapshot":{"counter":`+largeID+`}}`))
+		require.NoError(t, err)
+
+		snapshot := event.(*events.StateSnapshotEvent).Snapshot.(map[string]any)
+		assert.Equal(t, json.Number(largeID), snapshot["counter"])
+	})
+
+	t.Run("NewDecodingOptions keeps defaults", func(t *testing.T) {
+		opts := encoding.NewDecodingOptions()
+		assert.True(t, opts.Strict)
+		assert.True(t, opts.ValidateEvents)
+		assert.False(t, opts.UseNumber)
+	})
+}
+
+func TestJSONDecoderRegisteredCustomEvent(t *testing.T) {
+	type accountEvent struct {
+		AccountID int64   `json:"accountId"`
+		Ratio     float64 `json:"ratio"`
+	}
+	events.RegisterCustomEvent[accountEvent]("json-test-account")
+
+	data := []byte(`{"type":"CUSTOM","name":"json-test-account","value":{"accountId":9007199254740993,"ratio":0.5}}`)
+	decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUseNumber()))
+
+	event, err := decoder.Decode(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, accountEvent{AccountID: 9007199254740993, Ratio: 0.5}, event.(*events.CustomEvent).Value)
+
+	_, err = decoder.Decode(context.Background(), []byte(`{"type":"CUSTOM","name":"json-test-account","value":"oops"}`))
+	var decErr *encoding.DecodingError
+	require.ErrorAs(t, err, &decErr)
+	assert.Contains(t, err.Error(), "does not match registered type")
+}
+
+func TestJSONDecoderMaxEventBytes(t *testing.T) {
+	small := []byte(`{"type":"STEP_STARTED","stepName":"plan"}`)
+	large := []byte(`{"type":"CUSTOM","name":"blob","value":"` + strings.Repeat("x", 256) + `"}`)
+
+	decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithMaxEventBytes(128)))
+
+	_, err := decoder.Decode(context.Background(), small)
+	require.NoError(t, err)
+
+	_, err = decoder.Decode(context.Background(), large)
+	assert.ErrorIs(t, err, encoding.ErrEventTooLarge)
+
+	_, err = decoder.DecodeMultiple(context.Background(), []byte(`[`+string(small)+`,`+string(large)+`]`))
+	assert.ErrorIs(t, err, encoding.ErrEventTooLarge)
+	assert.Contains(t, err.Error(), "failed to decode event at index 1")
+
+	t.Run("defaults are finite", func(t *testing.T) {
+		assert.Equal(t, int64(encoding.DefaultMaxEventBytes), encoding.NewDecodingOptions().MaxEventBytes)
+		assert.Equal(t, int64(encoding.DefaultMaxEventBytes), NewJSONDecoder(nil).options.MaxEventBytes)
+		assert.Error(t, encoding.NewDecodingOptions(encoding.WithMaxEventBytes(-1)).Validate())
+	})
+
+	t.Run("codec defaults follow the option constructors", func(t *testing.T) {
+		codec := NewDefaultJSONCodec()
+		assert.Equal(t, encoding.NewDecodingOptions(), codec.JSONDecoder.options)
+		assert.Equal(t, encoding.NewEncodingOptions(), codec.JSONEncoder.options)
+
+		opts := DefaultCodecOptions()
+		assert.Equal(t, int64(encoding.DefaultMaxEventBytes), opts.DecodingOptions.MaxEventBytes)
+		assert.True(t, opts.DecodingOptions.Strict)
+	})
+
+	t.Run("zero removes the limit", func(t *testing.T) {
+		unlimited := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithMaxEventBytes(0)))
+		_, err := unlimited.Decode(context.Background(), large)
+		assert.NoError(t, err)
+	})
+}
+
+func TestJSONDecoderDecodeBatch(t *testing.T) {
+	decoder := NewJSONDecoder(nil)
+	ctx := context.Background()
+
+	wantTypes := []events.EventType{events.EventTypeRunStarted, events.EventTypeStepStarted, events.EventTypeRunFinished}
+	typesOf := func(decoded []events.Event) []events.EventType {
+		types := make([]events.EventType, len(decoded))
+		for i, event := range decoded {
+			types[i] = event.Type()
+		}
+		return types
+	}
+
+	t.Run("JSON array", func(t *testing.T) {
+		decoded, err := decoder.DecodeBatch(ctx, []byte(`
+			[{"type":"RUN_STARTED","threadId":"t","runId":"r"},
+			 {"type":"STEP_STARTED","stepName":"plan"},
+			 {"type":"RUN_FINISHED","threadId":"t","runId":"r"}]`))
+		require.NoError(t, err)
+		assert.Equal(t, wantTypes, typesOf(decoded))
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		decoded, err := decoder.DecodeBatch(ctx, []byte("{\"type\":\"RUN_STARTED\",\"threadId\":\"t\",\"runId\":\"r\"}\r\n"+
+			"\n"+
+			"{\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n"+
+			"{\"type\":\"RUN_FINISHED\",\"threadId\":\"t\",\"runId\":\"r\"}\n"))
+		require.NoError(t, err)
+		assert.Equal(t, wantTypes, typesOf(decoded))
+	})
+
+	t.Run("reports the failing line", func(t *testing.T) {
+		_, err := decoder.DecodeBatch(ctx, []byte("{\"type\":\"STEP_STARTED\",\"stepName\":\"plan\"}\n{\"type\":\"STEP_STARTED\"\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decode event on line 2")
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		decoded, err := decoder.DecodeBatch(ctx, []byte("  \n"))
+		require.NoError(t, err)
+		assert.Empty(t, decoded)
+	})
+}
+
+func TestJSONDecoderStructuredErrors(t *testing.T) {
+	decoder := NewJSONDecoder(nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		data       string
+		kind       error
+		field      string
+		wantOffset bool
+	}{
+		{
+			name:       "truncated input",
+			data:       `{"type":"TEXT_MESSAGE_CONTENT","messageId":"msg-1","del`,
+			kind:       encoding.ErrTruncatedInput,
+			wantOffset: true,
+		},
+		{
+			name:       "malformed input",
+			data:       `{"type":"TEXT_MESSAGE_CONTENT",,}`,
+			kind:       encoding.ErrMalformedInput,
+			wantOffset: true,
+		},
+		{
+			name:  "unknown event type",
+			data:  `{"type":"FUTURE_EVENT"}`,
+			kind:  encoding.ErrUnknownEventType,
+			field: "type",
+		},
+		{
+			name:       "field type mismatch",
+			data:       `{"type":"TEXT_MESSAGE_CONTENT","messageId":42,"delta":"hi"}`,
+			kind:       encoding.ErrFieldTypeMismatch,
+			field:      "messageId",
+			wantOffset: true,
+		},
+		{
+			name:  "unknown field in strict mode",
+			data:  `{"type":"TEXT_MESSAGE_END","messageId":"msg-1","extra":true}`,
+			kind:  encoding.ErrUnknownField,
+			field: "extra",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decoder.Decode(ctx, []byte(tt.data))
+			require.Error(t, err)
+
+			var decodingErr *encoding.DecodingError
+			require.ErrorAs(t, err, &decodingErr)
+
+			var decodeErr *encoding.DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			assert.ErrorIs(t, err, tt.kind)
+			assert.Equal(t, tt.field, decodeErr.Field)
+			if tt.wantOffset {
+				assert.Positive(t, decodeErr.Offset)
+			} else {
+				assert.Equal(t, -1, decodeErr.Offset)
+			}
+			assert.True(t, encoding.IsDecodeError(err))
+		})
+	}
+
+	t.Run("underlying json error remains reachable", func(t *testing.T) {
+		_, err := decoder.Decode(ctx, []byte(`{"type":"TEXT_MESSAGE_CONTENT","messageId":42,"delta":"hi"}`))
+
+		var typeErr *json.UnmarshalTypeError
+		assert.ErrorAs(t, err, &typeErr)
+	})
+
+	t.Run("array decoding reports the failing element", func(t *testing.T) {
+		_, err := decoder.DecodeMultiple(ctx, []byte(`[{"type":"TEXT_MESSAGE_END","messageId":"msg-1"},{"type":"NOPE"}]`))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, encoding.ErrUnknownEventType)
+		assert.Contains(t, err.Error(), "index 1")
+	})
+}
+
+func TestJSONCodecConformance(t *testing.T) {
+	encodingtest.RunConformance(t, NewJSONEncoder(nil), NewJSONDecoder(nil))
+}
+
+func FuzzJSONCodec(f *testing.F) {
+	encodingtest.FuzzConformance(f, NewJSONEncoder(nil), NewJSONDecoder(nil))
+}
+
+func TestJSONDecoderUnknownTypePolicy(t *testing.T) {
+	unknown := []byte(`{"type":"FUTURE_EVENT","payload":{"n":1}}`)
+	known := []byte(`{"type":"STEP_STARTED","stepName":"plan"}`)
+	ctx := context.Background()
+
+	t.Run("default rejects unknown types", func(t *testing.T) {
+		_, err := NewJSONDecoder(nil).Decode(ctx, unknown)
+		assert.ErrorIs(t, err, encoding.ErrUnknownEventType)
+		assert.Equal(t, encoding.UnknownTypeError, encoding.NewDecodingOptions().UnknownTypePolicy)
+	})
+
+	t.Run("PreserveAsRaw keeps the original bytes and type", func(t *testing.T) {
+		decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUnknownTypePolicy(encoding.UnknownTypePreserveAsRaw)))
+
+		event, err := decoder.Decode(ctx, unknown)
+		require.NoError(t, err)
+		raw, ok := event.(*events.RawEvent)
+		require.True(t, ok)
+		assert.Equal(t, json.RawMessage(unknown), raw.Event)
+		require.NotNil(t, raw.Source)
+		assert.Equal(t, "FUTURE_EVENT", *raw.Source)
+	})
+
+	t.Run("PreserveAsRaw re-encodes as a RAW wrapper", func(t *testing.T) {
+		decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUnknownTypePolicy(encoding.UnknownTypePreserveAsRaw)))
+
+		event, err := decoder.Decode(ctx, unknown)
+		require.NoError(t, err)
+		encoded, err := NewJSONEncoder(nil).Encode(ctx, event)
+		require.NoError(t, err)
+
+		var wrapper struct {
+			Type   events.EventType `json:"type"`
+			Event  json.RawMessage  `json:"event"`
+			Source string           `json:"source"`
+		}
+		require.NoError(t, json.Unmarshal(encoded, &wrapper))
+		assert.Equal(t, events.EventTypeRaw, wrapper.Type)
+		assert.Equal(t, "FUTURE_EVENT", wrapper.Source)
+		assert.JSONEq(t, string(unknown), string(wrapper.Event))
+
+		redecoded, err := decoder.Decode(ctx, encoded)
+		require.NoError(t, err)
+		raw, ok := redecoded.(*events.RawEvent)
+		require.True(t, ok)
+		payload, err := json.Marshal(raw.Event)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(unknown), string(payload))
+	})
+
+	t.Run("Skip drops unknown events", func(t *testing.T) {
+		decoder := NewJSONDecoder(encoding.NewDecodingOptions(encoding.WithUnknownTypePolicy(encoding.UnknownTypeSkip)))
+
+		_, err := decoder.Decode(ctx, unknown)
+		assert.ErrorIs(t, err, encoding.ErrEventSkipped)
+
+		decoded, err := decoder.DecodeMultiple(ctx, []byte(`[`+string(unknown)+`,`+string(known)+`]`))
+		require.NoError(t, err)
+		require.Len(t, decoded, 1)
+		assert.Equal(t, events.EventTypeStepStarted, decoded[0].Type())
+
+		decoded, err = decoder.DecodeBatch(ctx, []byte(string(known)+"\n"+string(unknown)+"\n"))
+		require.NoError(t, err)
+		assert.Len(t, decoded, 1)
+	})
+
+	t.Run("invalid policies are rejected", func(t *testing.T) {
+		assert.Error(t, encoding.NewDecodingOptions(encoding.WithUnknownTypePolicy(encoding.UnknownTypePolicy(7))).Validate())
+	})
+}
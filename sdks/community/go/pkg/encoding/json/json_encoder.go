@@ -28,10 +28,7 @@ type JSONEncoder struct {
 // NewJSONEncoder creates a new JSON encoder with the given options
 func NewJSONEncoder(options *encoding.EncodingOptions) *JSONEncoder {
 	if options == nil {
-		options = &encoding.EncodingOptions{
-			CrossSDKCompatibility: true,
-			ValidateOutput:        true,
-		}
+		options = encoding.NewEncodingOptions()
 	}
 	return &JSONEncoder{
 		options:       options,
@@ -42,10 +39,7 @@ func NewJSONEncoder(options *encoding.EncodingOptions) *JSONEncoder {
 // NewJSONEncoderWithConcurrencyLimit creates a new JSON encoder with specified concurrency limit
 func NewJSONEncoderWithConcurrencyLimit(options *encoding.EncodingOptions, maxConcurrent int32) *JSONEncoder {
 	if options == nil {
-		options = &encoding.EncodingOptions{
-			CrossSDKCompatibility: true,
-			ValidateOutput:        true,
-		}
+		options = encoding.NewEncodingOptions()
 	}
 	return &JSONEncoder{
 		options:       options,
@@ -55,6 +49,14 @@ func NewJSONEncoderWithConcurrencyLimit(options *encoding.EncodingOptions, maxCo
 
 // Encode encodes a single event to JSON
 func (e *JSONEncoder) Encode(ctx context.Context, event events.Event) ([]byte, error) {
+	data, err := e.encode(ctx, event)
+	if err == nil && e.options.SizeObserver != nil {
+		e.options.SizeObserver(event.Type(), len(data))
+	}
+	return data, err
+}
+
+func (e *JSONEncoder) encode(ctx context.Context, event events.Event) ([]byte, error) {
 	// Check context cancellation
 	if err := ctx.Err(); err != nil {
 		return nil, &encoding.EncodingError{
@@ -405,6 +407,12 @@ func (e *JSONEncoder) EncodeMultiple(ctx context.Context, events []events.Event)
 		}
 	}
 
+	if e.options.SizeObserver != nil {
+		for i, data := range encodedEvents {
+			e.options.SizeObserver(events[i].Type(), len(data))
+		}
+	}
+
 	return result, nil
 }
 
@@ -426,10 +434,7 @@ func (e *JSONEncoder) SupportsStreaming() bool {
 // Reset resets the encoder with new options (for pooling)
 func (e *JSONEncoder) Reset(options *encoding.EncodingOptions) {
 	if options == nil {
-		options = &encoding.EncodingOptions{
-			CrossSDKCompatibility: true,
-			ValidateOutput:        true,
-		}
+		options = encoding.NewEncodingOptions()
 	}
 	e.options = options
 }
@@ -0,0 +1,55 @@
+package json
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONEncoderSizeObserver(t *testing.T) {
+	type observation struct {
+		eventType events.EventType
+		bytes     int
+	}
+	var observed []observation
+
+	encoder := NewJSONEncoder(encoding.NewEncodingOptions(encoding.WithSizeObserver(func(eventType events.EventType, bytes int) {
+		observed = append(observed, observation{eventType, bytes})
+	})))
+	ctx := context.Background()
+
+	data, err := encoder.Encode(ctx, events.NewStepStartedEvent("plan"))
+	require.NoError(t, err)
+	assert.Equal(t, []observation{{events.EventTypeStepStarted, len(data)}}, observed)
+
+	t.Run("failed encodes are not observed", func(t *testing.T) {
+		observed = nil
+		_, err := encoder.Encode(ctx, events.NewStepStartedEvent(""))
+		require.Error(t, err)
+		assert.Empty(t, observed)
+	})
+
+	t.Run("EncodeMultiple observes each event", func(t *testing.T) {
+		observed = nil
+		_, err := encoder.EncodeMultiple(ctx, []events.Event{
+			events.NewStepStartedEvent("plan"),
+			events.NewStepFinishedEvent("plan"),
+		})
+		require.NoError(t, err)
+		require.Len(t, observed, 2)
+		assert.Equal(t, events.EventTypeStepStarted, observed[0].eventType)
+		assert.Equal(t, events.EventTypeStepFinished, observed[1].eventType)
+		assert.Positive(t, observed[1].bytes)
+	})
+
+	t.Run("NewEncodingOptions keeps defaults", func(t *testing.T) {
+		opts := encoding.NewEncodingOptions()
+		assert.True(t, opts.CrossSDKCompatibility)
+		assert.True(t, opts.ValidateOutput)
+		assert.Nil(t, opts.SizeObserver)
+	})
+}
@@ -0,0 +1,163 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// keepAliveFrame is an SSE comment frame; clients ignore it, but it keeps
+// proxies and load balancers from closing an idle connection
+const keepAliveFrame = ": keepalive\n\n"
+
+// minKeepAliveTick is the shortest period at which idleness is checked
+const minKeepAliveTick = time.Millisecond
+
+// KeepAliveWriter wraps a stream writer and writes a keepalive comment frame
+// whenever the stream has been idle for the configured interval.
+//
+// Writes from the keepalive goroutine and from callers are serialized, so as
+// long as each SSE frame is passed to Write in a single call (as SSEWriter
+// does) a keepalive can never land in the middle of a frame. Pass the
+// KeepAliveWriter, not the underlying writer, to SSEWriter.
+type KeepAliveWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	interval  time.Duration
+	lastWrite time.Time
+	err       error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewKeepAliveWriter starts sending keepalive frames to w after interval of
+// inactivity. Keepalives stop when ctx is done, when Stop is called, or after
+// the first failed write. A non-positive interval disables keepalives, in
+// which case the writer only serializes writes.
+func NewKeepAliveWriter(ctx context.Context, w io.Writer, interval time.Duration) *KeepAliveWriter {
+	k := &KeepAliveWriter{
+		w:         w,
+		interval:  interval,
+		lastWrite: time.Now(),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if interval <= 0 {
+		close(k.done)
+		return k
+	}
+
+	go k.run(ctx)
+	return k
+}
+
+// Write writes p to the underlying writer as a single unit. Once a keepalive
+// write has failed, Write returns that error without writing so the caller
+// notices the dead connection.
+func (k *KeepAliveWriter) Write(p []byte) (int, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.err != nil {
+		return 0, k.err
+	}
+
+	n, err := k.w.Write(p)
+	if err != nil {
+		k.err = err
+		return n, err
+	}
+	k.lastWrite = time.Now()
+	return n, nil
+}
+
+// Flush flushes the underlying writer if it supports flushing
+func (k *KeepAliveWriter) Flush() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.flushLocked()
+}
+
+// Stop stops sending keepalives and waits for the keepalive goroutine to exit.
+// It is safe to call more than once.
+func (k *KeepAliveWriter) Stop() {
+	k.stopOnce.Do(func() {
+		close(k.stop)
+	})
+	<-k.done
+}
+
+// Err returns the first write error seen on the underlying writer, if any
+func (k *KeepAliveWriter) Err() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.err
+}
+
+func (k *KeepAliveWriter) run(ctx context.Context) {
+	defer close(k.done)
+
+	// Check at a finer granularity than the interval so a keepalive goes out
+	// at most interval/2 late when the stream falls idle between ticks. Tiny
+	// intervals are clamped so the ticker period stays positive.
+	ticker := time.NewTicker(max(k.interval/2, minKeepAliveTick))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-k.stop:
+			return
+		case <-ticker.C:
+			if !k.writeKeepAliveIfIdle() {
+				return
+			}
+		}
+	}
+}
+
+// writeKeepAliveIfIdle writes a keepalive frame if nothing has been written
+// for a full interval. It reports whether keepalives should continue.
+func (k *KeepAliveWriter) writeKeepAliveIfIdle() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.err != nil {
+		return false
+	}
+
+	if time.Since(k.lastWrite) < k.interval {
+		return true
+	}
+
+	if _, err := io.WriteString(k.w, keepAliveFrame); err != nil {
+		k.err = fmt.Errorf("SSE keepalive write failed: %w", err)
+		return false
+	}
+	k.lastWrite = time.Now()
+
+	if err := k.flushLocked(); err != nil {
+		k.err = fmt.Errorf("SSE keepalive flush failed: %w", err)
+		return false
+	}
+
+	return true
+}
+
+func (k *KeepAliveWriter) flushLocked() error {
+	if f, ok := k.w.(flusher); ok {
+		return f.Flush()
+	}
+	if f, ok := k.w.(flusherWithoutError); ok {
+		f.Flush()
+	}
+	return nil
+}
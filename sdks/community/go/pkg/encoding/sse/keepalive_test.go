@@ -0,0 +1,181 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// lockedBuffer lets tests read output while the keepalive goroutine writes
+type lockedBuffer struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	flushes int
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushes++
+	return nil
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met before timeout")
+}
+
+func TestKeepAliveWriter(t *testing.T) {
+	t.Run("writes keepalive when idle", func(t *testing.T) {
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(context.Background(), out, 20*time.Millisecond)
+		defer kw.Stop()
+
+		waitFor(t, time.Second, func() bool {
+			return strings.Contains(out.String(), keepAliveFrame)
+		})
+
+		out.mu.Lock()
+		flushes := out.flushes
+		out.mu.Unlock()
+		if flushes == 0 {
+			t.Error("expected keepalive to be flushed")
+		}
+	})
+
+	t.Run("tiny intervals do not panic", func(t *testing.T) {
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(context.Background(), out, time.Nanosecond)
+		defer kw.Stop()
+
+		waitFor(t, time.Second, func() bool {
+			return strings.Contains(out.String(), keepAliveFrame)
+		})
+	})
+
+	t.Run("no keepalive while events are flowing", func(t *testing.T) {
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(context.Background(), out, 100*time.Millisecond)
+		defer kw.Stop()
+
+		writer := NewSSEWriter()
+		for i := 0; i < 10; i++ {
+			if err := writer.WriteEvent(context.Background(), kw, events.NewTextMessageContentEvent("msg-1", "hi")); err != nil {
+				t.Fatalf("WriteEvent() error = %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		if strings.Contains(out.String(), keepAliveFrame) {
+			t.Errorf("unexpected keepalive on active stream: %q", out.String())
+		}
+	})
+
+	t.Run("keepalives interleave without tearing frames", func(t *testing.T) {
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(context.Background(), out, 2*time.Millisecond)
+
+		writer := NewSSEWriter()
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 25; i++ {
+					event := events.NewTextMessageContentEvent("msg-1", strings.Repeat("x", 512))
+					if err := writer.WriteEvent(context.Background(), kw, event); err != nil {
+						t.Errorf("WriteEvent() error = %v", err)
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}()
+		}
+		wg.Wait()
+		time.Sleep(10 * time.Millisecond)
+		kw.Stop()
+
+		dataFrames := 0
+		for _, frame := range strings.Split(strings.TrimSuffix(out.String(), "\n\n"), "\n\n") {
+			if frame == strings.TrimSuffix(keepAliveFrame, "\n\n") {
+				continue
+			}
+			lines := strings.Split(frame, "\n")
+			if len(lines) != 2 || !strings.HasPrefix(lines[0], "id: ") || !strings.HasPrefix(lines[1], "data: {") || !strings.HasSuffix(lines[1], "}") {
+				t.Fatalf("torn frame: %q", frame)
+			}
+			dataFrames++
+		}
+		if dataFrames != 100 {
+			t.Errorf("got %d data frames, want 100", dataFrames)
+		}
+	})
+
+	t.Run("stops on context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(ctx, out, 10*time.Millisecond)
+
+		cancel()
+		kw.Stop()
+
+		before := out.String()
+		time.Sleep(30 * time.Millisecond)
+		if out.String() != before {
+			t.Error("keepalive written after context cancellation")
+		}
+	})
+
+	t.Run("write failure is reported to callers", func(t *testing.T) {
+		writeErr := errors.New("connection reset")
+		kw := NewKeepAliveWriter(context.Background(), &errorWriter{err: writeErr}, 10*time.Millisecond)
+
+		waitFor(t, time.Second, func() bool { return kw.Err() != nil })
+		kw.Stop()
+
+		if !errors.Is(kw.Err(), writeErr) {
+			t.Errorf("Err() = %v, want %v", kw.Err(), writeErr)
+		}
+		if _, err := kw.Write([]byte("data: {}\n\n")); !errors.Is(err, writeErr) {
+			t.Errorf("Write() error = %v, want %v", err, writeErr)
+		}
+	})
+
+	t.Run("non-positive interval disables keepalives", func(t *testing.T) {
+		out := &lockedBuffer{}
+		kw := NewKeepAliveWriter(context.Background(), out, 0)
+		kw.Stop()
+
+		if _, err := kw.Write([]byte("data: {}\n\n")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if got := out.String(); got != "data: {}\n\n" {
+			t.Errorf("output = %q", got)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+const (
+	// defaultReplaySize is the number of recent events kept per run.
+	defaultReplaySize = 1024
+	// defaultReplayRetention is how long a finished run stays replayable.
+	defaultReplayRetention = 30 * time.Second
+	// replaySubscriberBuffer bounds how far a live subscriber may fall behind
+	// before it is disconnected and has to resume from its Last-Event-ID.
+	replaySubscriberBuffer = 256
+)
+
+var (
+	// ErrReplayUnavailable is returned when the requested events can no longer
+	// be replayed, either because the run is unknown or expired, or because
+	// events after the given ID were already evicted from the ring buffer.
+	ErrReplayUnavailable = errors.New("sse: events are no longer available for replay")
+
+	// ErrInvalidLastEventID is returned when a Last-Event-ID was not issued by
+	// a ReplayBuffer.
+	ErrInvalidLastEventID = errors.New("sse: invalid Last-Event-ID")
+
+	// ErrReplayGap is returned by Resume when the subscriber fell too far
+	// behind the live stream and was disconnected. The client should
+	// reconnect with its Last-Event-ID to pick up where it left off.
+	ErrReplayGap = errors.New("sse: subscriber fell behind the live stream")
+)
+
+// BufferedEvent is an event recorded in a ReplayBuffer together with the SSE
+// event ID it was assigned
+type BufferedEvent struct {
+	ID    string
+	Event events.Event
+}
+
+// ReplayBuffer keeps a bounded ring of recent events per run so that a client
+// reconnecting with a Last-Event-ID header can be sent what it missed before
+// live delivery resumes.
+//
+// Event IDs have the form "<runID>:<sequence>", so a Last-Event-ID alone
+// identifies both the run and the position within it. Sequence numbers are
+// never reused within a buffer, even when a run ID is recorded again after
+// it finished. Recorded events are retained by reference; do not Release
+// pooled events that were recorded.
+type ReplayBuffer struct {
+	mu        sync.Mutex
+	runs      map[string]*replayRun
+	size      int
+	retention time.Duration
+	nextBase  uint64           // first sequence number of the next new run
+	now       func() time.Time // injectable clock for tests
+}
+
+type replayRun struct {
+	entries     []BufferedEvent // ring of at most size entries
+	start       int             // index of the oldest entry in entries
+	first       uint64          // sequence number of the run's first event
+	next        uint64          // sequence number of the next recorded event
+	finished    bool
+	finishedAt  time.Time
+	subscribers map[chan BufferedEvent]struct{}
+}
+
+// ReplayOption configures a ReplayBuffer
+type ReplayOption func(*ReplayBuffer)
+
+// WithReplaySize sets how many recent events are kept per run. Values below
+// one are ignored.
+func WithReplaySize(size int) ReplayOption {
+	return func(b *ReplayBuffer) {
+		if size > 0 {
+			b.size = size
+		}
+	}
+}
+
+// WithReplayRetention sets how long a run remains replayable after Finish.
+// Zero drops the buffer as soon as the run finishes.
+func WithReplayRetention(retention time.Duration) ReplayOption {
+	return func(b *ReplayBuffer) {
+		if retention >= 0 {
+			b.retention = retention
+		}
+	}
+}
+
+// NewReplayBuffer creates an empty replay buffer
+func NewReplayBuffer(options ...ReplayOption) *ReplayBuffer {
+	b := &ReplayBuffer{
+		runs:      make(map[string]*replayRun),
+		size:      defaultReplaySize,
+		retention: defaultReplayRetention,
+		now:       time.Now,
+	}
+
+	for _, opt := range options {
+		opt(b)
+	}
+
+	return b
+}
+
+// Record appends an event to the run's buffer, delivers it to live
+// subscribers, and returns the SSE event ID assigned to it. A RUN_FINISHED or
+// RUN_ERROR event finishes the run as Finish does. Recording on a finished
+// run starts a new buffer whose sequence numbers continue above every ID
+// issued so far, so a Last-Event-ID from the earlier run cannot resume into
+// it.
+func (b *ReplayBuffer) Record(runID string, event events.Event) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expireLocked()
+
+	run := b.runs[runID]
+	if run == nil || run.finished {
+		run = &replayRun{first: b.nextBase, next: b.nextBase, subscribers: make(map[chan BufferedEvent]struct{})}
+		b.runs[runID] = run
+	}
+
+	entry := BufferedEvent{ID: formatReplayID(runID, run.next), Event: event}
+	run.next++
+	b.nextBase = max(b.nextBase, run.next)
+
+	if len(run.entries) < b.size {
+		run.entries = append(run.entries, entry)
+	} else {
+		run.entries[run.start] = entry
+		run.start = (run.start + 1) % len(run.entries)
+	}
+
+	for ch := range run.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			// The subscriber fell too far behind; disconnect it so it resumes
+			// from its Last-Event-ID instead of silently skipping events.
+			delete(run.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	if event != nil {
+		switch event.Type() {
+		case events.EventTypeRunFinished, events.EventTypeRunError:
+			b.finishLocked(runID)
+		}
+	}
+
+	return entry.ID
+}
+
+// WriteEvent records an event for runID and writes it to w using its replay
+// ID, so the client's Last-Event-ID can later resume from it
+func (b *ReplayBuffer) WriteEvent(ctx context.Context, sw *SSEWriter, w io.Writer, runID string, event events.Event) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	return sw.WriteEventWithID(ctx, w, event, b.Record(runID, event))
+}
+
+// Finish marks the run as finished and disconnects its live subscribers. The
+// buffer remains replayable for the configured retention and is then dropped.
+// Runs that end with a recorded RUN_FINISHED or RUN_ERROR are finished
+// automatically; call Finish for runs that end without one.
+func (b *ReplayBuffer) Finish(runID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.finishLocked(runID)
+}
+
+func (b *ReplayBuffer) finishLocked(runID string) {
+	run := b.runs[runID]
+	if run == nil {
+		return
+	}
+
+	for ch := range run.subscribers {
+		close(ch)
+	}
+	run.subscribers = nil
+	run.finished = true
+	run.finishedAt = b.now()
+
+	if b.retention == 0 {
+		delete(b.runs, runID)
+	}
+}
+
+// Since returns the buffered events recorded after lastEventID
+func (b *ReplayBuffer) Since(lastEventID string) ([]BufferedEvent, error) {
+	backlog, _, _, _, err := b.subscribe(lastEventID, false)
+	return backlog, err
+}
+
+// Subscribe returns the buffered events recorded after lastEventID together
+// with a channel of events recorded from then on, with no gap in between. The
+// channel is closed when the run finishes or the subscriber falls behind;
+// cancel releases the subscription early. For a finished run the channel is
+// already closed.
+func (b *ReplayBuffer) Subscribe(lastEventID string) (backlog []BufferedEvent, live <-chan BufferedEvent, cancel func(), err error) {
+	backlog, live, _, cancel, err = b.subscribe(lastEventID, true)
+	return backlog, live, cancel, err
+}
+
+// Resume writes every event the client missed after lastEventID to w and
+// then streams live events until the run finishes, the subscription falls
+// behind, or ctx is done. It returns nil once the run finishes and
+// ErrReplayGap if the subscriber was disconnected for falling behind.
+func (b *ReplayBuffer) Resume(ctx context.Context, sw *SSEWriter, w io.Writer, lastEventID string) error {
+	backlog, live, run, cancel, err := b.subscribe(lastEventID, true)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	for _, entry := range backlog {
+		if err := sw.WriteEventWithID(ctx, w, entry.Event, entry.ID); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case entry, ok := <-live:
+			if !ok {
+				if b.isFinished(run) {
+					return nil
+				}
+				return ErrReplayGap
+			}
+			if err := sw.WriteEventWithID(ctx, w, entry.Event, entry.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *ReplayBuffer) subscribe(lastEventID string, live bool) ([]BufferedEvent, <-chan BufferedEvent, *replayRun, func(), error) {
+	runID, seq, err := parseReplayID(lastEventID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expireLocked()
+
+	// IDs below the run's first sequence number were issued to an earlier
+	// run with the same ID and must not resume into this one
+	run := b.runs[runID]
+	if run == nil || seq < run.first || seq >= run.next {
+		return nil, nil, nil, nil, ErrReplayUnavailable
+	}
+
+	// The oldest retained event must directly follow or precede the client's
+	// last event, otherwise something in between was evicted.
+	oldest := run.next - uint64(len(run.entries))
+	if seq+1 < oldest {
+		return nil, nil, nil, nil, ErrReplayUnavailable
+	}
+
+	missed := int(run.next - seq - 1)
+	backlog := make([]BufferedEvent, 0, missed)
+	for i := len(run.entries) - missed; i < len(run.entries); i++ {
+		backlog = append(backlog, run.entries[(run.start+i)%len(run.entries)])
+	}
+
+	if !live {
+		return backlog, nil, run, nil, nil
+	}
+
+	ch := make(chan BufferedEvent, replaySubscriberBuffer)
+	if run.finished {
+		close(ch)
+		return backlog, ch, run, func() {}, nil
+	}
+	run.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := run.subscribers[ch]; ok {
+			delete(run.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return backlog, ch, run, cancel, nil
+}
+
+// isFinished reports whether run was finished, as opposed to a subscriber
+// having been disconnected from it
+func (b *ReplayBuffer) isFinished(run *replayRun) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return run.finished
+}
+
+// expireLocked drops finished runs whose retention has elapsed
+func (b *ReplayBuffer) expireLocked() {
+	now := b.now()
+	for runID, run := range b.runs {
+		if run.finished && now.Sub(run.finishedAt) >= b.retention {
+			delete(b.runs, runID)
+		}
+	}
+}
+
+func formatReplayID(runID string, seq uint64) string {
+	return runID + ":" + strconv.FormatUint(seq, 10)
+}
+
+func parseReplayID(id string) (string, uint64, error) {
+	sep := strings.LastIndexByte(id, ':')
+	if sep <= 0 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidLastEventID, id)
+	}
+
+	seq, err := strconv.ParseUint(id[sep+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidLastEventID, id)
+	}
+
+	return id[:sep], seq, nil
+}
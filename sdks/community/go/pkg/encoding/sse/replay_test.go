@@ -0,0 +1,324 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+func recordDeltas(b *ReplayBuffer, runID string, deltas ...string) []string {
+	ids := make([]string, len(deltas))
+	for i, delta := range deltas {
+		ids[i] = b.Record(runID, events.NewTextMessageContentEvent("msg-1", delta))
+	}
+	return ids
+}
+
+func deltasOf(entries []BufferedEvent) []string {
+	deltas := make([]string, len(entries))
+	for i, entry := range entries {
+		deltas[i] = entry.Event.(*events.TextMessageContentEvent).Delta
+	}
+	return deltas
+}
+
+func TestReplayBuffer_Since(t *testing.T) {
+	b := NewReplayBuffer()
+	ids := recordDeltas(b, "run-1", "a", "b", "c")
+
+	if ids[0] != "run-1:0" || ids[2] != "run-1:2" {
+		t.Fatalf("unexpected IDs: %v", ids)
+	}
+
+	backlog, err := b.Since(ids[0])
+	if err != nil {
+		t.Fatalf("Since() error = %v", err)
+	}
+	if got := strings.Join(deltasOf(backlog), ""); got != "bc" {
+		t.Errorf("replayed %q, want %q", got, "bc")
+	}
+
+	backlog, err = b.Since(ids[2])
+	if err != nil {
+		t.Fatalf("Since() error = %v", err)
+	}
+	if len(backlog) != 0 {
+		t.Errorf("expected nothing to replay after the latest event, got %d", len(backlog))
+	}
+}
+
+func TestReplayBuffer_RingEviction(t *testing.T) {
+	b := NewReplayBuffer(WithReplaySize(3))
+	ids := recordDeltas(b, "run-1", "a", "b", "c", "d", "e")
+
+	backlog, err := b.Since(ids[1])
+	if err != nil {
+		t.Fatalf("Since() error = %v", err)
+	}
+	if got := strings.Join(deltasOf(backlog), ""); got != "cde" {
+		t.Errorf("replayed %q, want %q", got, "cde")
+	}
+
+	if _, err := b.Since(ids[0]); !errors.Is(err, ErrReplayUnavailable) {
+		t.Errorf("expected ErrReplayUnavailable for evicted events, got %v", err)
+	}
+}
+
+func TestReplayBuffer_Errors(t *testing.T) {
+	b := NewReplayBuffer()
+	recordDeltas(b, "run-1", "a")
+
+	for _, id := range []string{"", "run-1", ":3", "run-1:x"} {
+		if _, err := b.Since(id); !errors.Is(err, ErrInvalidLastEventID) {
+			t.Errorf("Since(%q) error = %v, want ErrInvalidLastEventID", id, err)
+		}
+	}
+
+	for _, id := range []string{"run-2:0", "run-1:5"} {
+		if _, err := b.Since(id); !errors.Is(err, ErrReplayUnavailable) {
+			t.Errorf("Since(%q) error = %v, want ErrReplayUnavailable", id, err)
+		}
+	}
+}
+
+func TestReplayBuffer_Retention(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewReplayBuffer(WithReplayRetention(time.Minute))
+	b.now = func() time.Time { return now }
+
+	ids := recordDeltas(b, "run-1", "a", "b")
+	b.Finish("run-1")
+
+	now = now.Add(30 * time.Second)
+	if _, err := b.Since(ids[0]); err != nil {
+		t.Errorf("finished run should remain replayable within retention: %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := b.Since(ids[0]); !errors.Is(err, ErrReplayUnavailable) {
+		t.Errorf("expected buffer to be dropped after retention, got %v", err)
+	}
+
+	immediate := NewReplayBuffer(WithReplayRetention(0))
+	ids = recordDeltas(immediate, "run-1", "a", "b")
+	immediate.Finish("run-1")
+	if _, err := immediate.Since(ids[0]); !errors.Is(err, ErrReplayUnavailable) {
+		t.Errorf("expected buffer to be dropped on finish, got %v", err)
+	}
+}
+
+func TestReplayBuffer_RestartedRunDoesNotReuseIDs(t *testing.T) {
+	for _, retention := range []time.Duration{time.Minute, 0} {
+		b := NewReplayBuffer(WithReplayRetention(retention))
+		old := recordDeltas(b, "run-1", "a", "b", "c")
+		b.Finish("run-1")
+
+		restarted := recordDeltas(b, "run-1", "x", "y")
+		if restarted[0] != "run-1:3" {
+			t.Errorf("retention %v: restarted run began at %s, want run-1:3", retention, restarted[0])
+		}
+
+		for _, id := range []string{old[0], old[len(old)-1]} {
+			if _, err := b.Since(id); !errors.Is(err, ErrReplayUnavailable) {
+				t.Errorf("retention %v: ID %s from the earlier run resumed into the new one: %v", retention, id, err)
+			}
+		}
+
+		backlog, err := b.Since(restarted[0])
+		if err != nil {
+			t.Fatalf("retention %v: Since(%s) error = %v", retention, restarted[0], err)
+		}
+		if len(backlog) != 1 || backlog[0].ID != restarted[1] {
+			t.Errorf("retention %v: Since(%s) = %v, want only %s", retention, restarted[0], backlog, restarted[1])
+		}
+	}
+}
+
+func TestReplayBuffer_FinishesOnTerminalEvents(t *testing.T) {
+	for _, terminal := range []events.Event{
+		events.NewRunFinishedEvent("thread-1", "run-1"),
+		events.NewRunErrorEvent("boom"),
+	} {
+		b := NewReplayBuffer()
+		ids := recordDeltas(b, "run-1", "a")
+
+		_, live, cancel, err := b.Subscribe(ids[0])
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		b.Record("run-1", terminal)
+
+		if entry := <-live; entry.Event != terminal {
+			t.Errorf("expected %s to be delivered, got %+v", terminal.Type(), entry)
+		}
+		if _, ok := <-live; ok {
+			t.Errorf("expected live channel to close after %s", terminal.Type())
+		}
+		cancel()
+	}
+}
+
+func TestReplayBuffer_Subscribe(t *testing.T) {
+	b := NewReplayBuffer()
+	ids := recordDeltas(b, "run-1", "a", "b")
+
+	backlog, live, cancel, err := b.Subscribe(ids[0])
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancel()
+
+	if got := strings.Join(deltasOf(backlog), ""); got != "b" {
+		t.Errorf("backlog %q, want %q", got, "b")
+	}
+
+	recordDeltas(b, "run-1", "c")
+	entry := <-live
+	if entry.ID != "run-1:2" || entry.Event.(*events.TextMessageContentEvent).Delta != "c" {
+		t.Errorf("unexpected live event %+v", entry)
+	}
+
+	b.Finish("run-1")
+	if _, ok := <-live; ok {
+		t.Error("expected live channel to close when the run finishes")
+	}
+
+	// cancel after Finish must not panic
+	cancel()
+}
+
+func TestReplayBuffer_SlowSubscriberIsDisconnected(t *testing.T) {
+	b := NewReplayBuffer(WithReplaySize(replaySubscriberBuffer * 2))
+	ids := recordDeltas(b, "run-1", "a")
+
+	_, live, cancel, err := b.Subscribe(ids[0])
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancel()
+
+	for i := 0; i <= replaySubscriberBuffer; i++ {
+		recordDeltas(b, "run-1", "x")
+	}
+
+	received := 0
+	for range live {
+		received++
+	}
+	if received != replaySubscriberBuffer {
+		t.Errorf("received %d events before disconnect, want %d", received, replaySubscriberBuffer)
+	}
+}
+
+func TestReplayBuffer_Resume(t *testing.T) {
+	b := NewReplayBuffer()
+	sw := NewSSEWriter()
+	ctx := context.Background()
+
+	var first bytes.Buffer
+	for _, delta := range []string{"a", "b"} {
+		event := events.NewTextMessageContentEvent("msg-1", delta)
+		if err := b.WriteEvent(ctx, sw, &first, "run-1", event); err != nil {
+			t.Fatalf("WriteEvent() error = %v", err)
+		}
+	}
+	if !strings.Contains(first.String(), "id: run-1:1\n") {
+		t.Fatalf("expected replay IDs in frames, got %q", first.String())
+	}
+	// The client only saw the first event; the run keeps producing.
+	const lastID = "run-1:0"
+	recordDeltas(b, "run-1", "c")
+
+	done := make(chan error, 1)
+	var resumed lockedBuffer
+	go func() {
+		done <- b.Resume(ctx, sw, &resumed, lastID)
+	}()
+
+	waitFor(t, time.Second, func() bool {
+		return strings.Contains(resumed.String(), "id: run-1:2\n")
+	})
+
+	if err := b.WriteEvent(ctx, sw, &bytes.Buffer{}, "run-1", events.NewTextMessageContentEvent("msg-1", "d")); err != nil {
+		t.Fatalf("WriteEvent() error = %v", err)
+	}
+	waitFor(t, time.Second, func() bool {
+		return strings.Contains(resumed.String(), "id: run-1:3\n")
+	})
+
+	b.Finish("run-1")
+	if err := <-done; err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+
+	out := resumed.String()
+	for _, want := range []string{`"delta":"b"`, `"delta":"c"`, `"delta":"d"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("resumed stream missing %s: %q", want, out)
+		}
+	}
+	if strings.Contains(out, `"delta":"a"`) {
+		t.Errorf("resumed stream replayed an event the client already had: %q", out)
+	}
+}
+
+func TestSSEWriter_WriteEventWithID(t *testing.T) {
+	sw := NewSSEWriter()
+	var buf bytes.Buffer
+
+	event := events.NewTextMessageContentEvent("msg-1", "hi")
+	if err := sw.WriteEventWithID(context.Background(), &buf, event, "run-1:7"); err != nil {
+		t.Fatalf("WriteEventWithID() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "id: run-1:7\ndata: ") {
+		t.Errorf("unexpected frame %q", buf.String())
+	}
+
+	if err := sw.WriteEventWithID(context.Background(), &buf, event, "bad\nid"); err == nil {
+		t.Error("expected error for ID containing a newline")
+	}
+}
+
+func TestReplayBuffer_ResumeReportsGap(t *testing.T) {
+	b := NewReplayBuffer(WithReplaySize(replaySubscriberBuffer * 2))
+	sw := NewSSEWriter()
+	ids := recordDeltas(b, "run-1", "a")
+
+	// Block every write so the subscription falls behind
+	unblock := make(chan struct{})
+	w := writerFunc(func(p []byte) (int, error) {
+		<-unblock
+		return len(p), nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Resume(context.Background(), sw, w, ids[0])
+	}()
+
+	recordDeltas(b, "run-1", "b")
+	waitFor(t, time.Second, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.runs["run-1"].subscribers) == 1
+	})
+	for i := 0; i <= replaySubscriberBuffer+1; i++ {
+		recordDeltas(b, "run-1", "x")
+	}
+	close(unblock)
+
+	if err := <-done; !errors.Is(err, ErrReplayGap) {
+		t.Fatalf("Resume() error = %v, want ErrReplayGap", err)
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
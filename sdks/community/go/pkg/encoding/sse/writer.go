@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/encoder"
 )
 
@@ -21,10 +22,11 @@ type SSEWriter struct {
 	logger  *slog.Logger
 }
 
-// NewSSEWriter creates a new SSE writer
-func NewSSEWriter() *SSEWriter {
+// NewSSEWriter creates a new SSE writer. The options configure how events are
+// encoded, so a SizeObserver given here sees every event the writer sends.
+func NewSSEWriter(options ...encoding.EncodingOption) *SSEWriter {
 	return &SSEWriter{
-		encoder: encoder.NewEventEncoder(),
+		encoder: encoder.NewEventEncoder(options...),
 		logger:  slog.Default(),
 	}
 }
@@ -76,6 +78,21 @@ func (w *SSEWriter) WriteBytes(ctx context.Context, writer io.Writer, event []by
 
 // WriteEventWithType writes an event with a specific SSE event type
 func (w *SSEWriter) WriteEventWithType(ctx context.Context, writer io.Writer, event events.Event, eventType string) error {
+	return w.writeEvent(ctx, writer, event, eventType, "")
+}
+
+// WriteEventWithID writes an event with an explicit SSE event ID in place of
+// the default type/timestamp ID. Use it when clients reconnect with a
+// Last-Event-ID header and the ID must identify the event uniquely.
+func (w *SSEWriter) WriteEventWithID(ctx context.Context, writer io.Writer, event events.Event, id string) error {
+	if strings.ContainsAny(id, "\r\n") {
+		return fmt.Errorf("SSE event ID cannot contain newlines")
+	}
+	return w.writeEvent(ctx, writer, event, "", id)
+}
+
+// writeEvent encodes and writes an event; an empty id selects the default ID
+func (w *SSEWriter) writeEvent(ctx context.Context, writer io.Writer, event events.Event, eventType, id string) error {
 	if event == nil {
 		return fmt.Errorf("event cannot be nil")
 	}
@@ -94,7 +111,10 @@ func (w *SSEWriter) WriteEventWithType(ctx context.Context, writer io.Writer, ev
 	}
 
 	// Create SSE frame
-	sseFrame, err := w.createSSEFrame(jsonData, eventType, event)
+	if id == "" {
+		id = defaultEventID(event)
+	}
+	sseFrame, err := w.createSSEFrameWithID(jsonData, eventType, id)
 	if err != nil {
 		w.logger.ErrorContext(ctx, "Failed to create SSE frame",
 			"error", err,
@@ -164,6 +184,12 @@ func (w *SSEWriter) WriteErrorEvent(ctx context.Context, writer io.Writer, err e
 
 // createSSEFrame creates a properly formatted SSE frame
 func (w *SSEWriter) createSSEFrame(jsonData []byte, eventType string, event events.Event) (string, error) {
+	return w.createSSEFrameWithID(jsonData, eventType, defaultEventID(event))
+}
+
+// createSSEFrameWithID creates an SSE frame with the given ID; an empty id
+// omits the id line
+func (w *SSEWriter) createSSEFrameWithID(jsonData []byte, eventType, id string) (string, error) {
 	var frame strings.Builder
 
 	// Add event type if specified
@@ -172,8 +198,8 @@ func (w *SSEWriter) createSSEFrame(jsonData []byte, eventType string, event even
 	}
 
 	// Add event ID if available
-	if event != nil && event.Timestamp() != nil {
-		frame.WriteString(fmt.Sprintf("id: %s_%d\n", event.Type(), *event.Timestamp()))
+	if id != "" {
+		frame.WriteString(fmt.Sprintf("id: %s\n", id))
 	}
 
 	// Escape newlines in JSON data to maintain SSE format integrity
@@ -189,6 +215,14 @@ func (w *SSEWriter) createSSEFrame(jsonData []byte, eventType string, event even
 	return frame.String(), nil
 }
 
+// defaultEventID derives an SSE event ID from the event type and timestamp
+func defaultEventID(event events.Event) string {
+	if event == nil || event.Timestamp() == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s_%d", event.Type(), *event.Timestamp())
+}
+
 // flusher interface for writers that support flushing
 type flusher interface {
 	Flush() error
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/encodingtest"
 )
 
 type mockEvent struct {
@@ -99,6 +101,34 @@ func TestSSEWriter_WithLogger(t *testing.T) {
 	}
 }
 
+func TestSSEWriter_EncodingOptions(t *testing.T) {
+	var sizes []int
+	writer := NewSSEWriter(encoding.WithSizeObserver(func(eventType events.EventType, bytes int) {
+		if eventType != events.EventTypeRunStarted {
+			t.Errorf("expected %s event, got %s", events.EventTypeRunStarted, eventType)
+		}
+		sizes = append(sizes, bytes)
+	}))
+
+	var buf bytes.Buffer
+	if err := writer.WriteEvent(context.Background(), &buf, events.NewRunStartedEvent("thread-1", "run-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sizes) != 1 {
+		t.Fatalf("expected one observed size, got %d", len(sizes))
+	}
+	var payload string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			payload = data
+		}
+	}
+	if sizes[0] != len(payload) {
+		t.Errorf("expected observed size %d, got %d", len(payload), sizes[0])
+	}
+}
+
 func TestSSEWriter_WriteEvent(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -705,3 +735,20 @@ func TestCreateSSEFrame(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+func TestSSEWriter_WriteEventNonFinite(t *testing.T) {
+	sw := NewSSEWriter()
+
+	for _, c := range encodingtest.NonFiniteCases() {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := sw.WriteEvent(context.Background(), &buf, c.Event)
+			if !errors.Is(err, events.ErrNonFiniteNumber) {
+				t.Fatalf("WriteEvent() error = %v, want ErrNonFiniteNumber", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("expected nothing written, got %q", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package encoding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+)
+
+// ValidatingDecoder wraps a Decoder and runs every decoded event through an
+// events.Validator before returning it, so malformed or out-of-sequence input
+// is rejected at ingress in a single pass.
+//
+// The validator is stateful, so a ValidatingDecoder should be used for a
+// single stream. Calls are serialized, which makes it safe for concurrent
+// use, but events from different goroutines are then validated in whatever
+// order they arrive.
+type ValidatingDecoder struct {
+	inner Decoder
+
+	mu        sync.Mutex
+	validator *events.Validator
+}
+
+// NewValidatingDecoder returns a decoder that validates events decoded by
+// inner. A nil validator uses events.DefaultValidationConfig.
+func NewValidatingDecoder(inner Decoder, validator *events.Validator) *ValidatingDecoder {
+	if validator == nil {
+		validator = events.NewValidator(events.DefaultValidationConfig())
+	}
+	return &ValidatingDecoder{inner: inner, validator: validator}
+}
+
+// Decode decodes a single event and validates it against the stream so far
+func (d *ValidatingDecoder) Decode(ctx context.Context, data []byte) (events.Event, error) {
+	event, err := d.inner.Decode(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.validator.ValidateEvent(event); err != nil {
+		return nil, &DecodingError{
+			Format:  d.inner.ContentType(),
+			Data:    data,
+			Message: "decoded event failed validation",
+			Cause:   err,
+		}
+	}
+
+	return event, nil
+}
+
+// DecodeMultiple decodes a batch of events and validates them in order,
+// failing on the first invalid event
+func (d *ValidatingDecoder) DecodeMultiple(ctx context.Context, data []byte) ([]events.Event, error) {
+	decoded, err := d.inner.DecodeMultiple(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, event := range decoded {
+		if err := d.validator.ValidateEvent(event); err != nil {
+			return nil, &DecodingError{
+				Format:  d.inner.ContentType(),
+				Data:    data,
+				Message: fmt.Sprintf("decoded event %d failed validation", i),
+				Cause:   err,
+			}
+		}
+	}
+
+	return decoded, nil
+}
+
+// ContentType returns the content type of the inner decoder
+func (d *ValidatingDecoder) ContentType() string {
+	return d.inner.ContentType()
+}
@@ -0,0 +1,86 @@
+package encoding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/core/events"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding"
+	"github.com/ag-ui-protocol/ag-ui/sdks/community/go/pkg/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatingDecoder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes valid events through", func(t *testing.T) {
+		dec := encoding.NewValidatingDecoder(json.NewJSONDecoder(nil), nil)
+		assert.Equal(t, "application/json", dec.ContentType())
+
+		event, err := dec.Decode(ctx, []byte(`{"type":"RUN_STARTED","threadId":"thread-1","runId":"run-1"}`))
+		require.NoError(t, err)
+		assert.Equal(t, events.EventTypeRunStarted, event.Type())
+
+		event, err = dec.Decode(ctx, []byte(`{"type":"TEXT_MESSAGE_START","messageId":"msg-1","role":"assistant"}`))
+		require.NoError(t, err)
+		assert.Equal(t, events.EventTypeTextMessageStart, event.Type())
+	})
+
+	t.Run("rejects events that break the sequence", func(t *testing.T) {
+		dec := encoding.NewValidatingDecoder(json.NewJSONDecoder(nil), nil)
+
+		event, err := dec.Decode(ctx, []byte(`{"type":"TEXT_MESSAGE_CONTENT","messageId":"msg-1","delta":"hi"}`))
+		assert.Nil(t, event)
+		require.Error(t, err)
+
+		var decErr *encoding.DecodingError
+		require.True(t, errors.As(err, &decErr))
+		assert.Equal(t, "decoded event failed validation", decErr.Message)
+		assert.Contains(t, err.Error(), "cannot add content to message msg-1 that was not started")
+	})
+
+	t.Run("returns decode errors unchanged", func(t *testing.T) {
+		dec := encoding.NewValidatingDecoder(json.NewJSONDecoder(nil), nil)
+		_, err := dec.Decode(ctx, []byte(`{not json`))
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "failed validation")
+	})
+
+	t.Run("uses the given validator configuration", func(t *testing.T) {
+		validator := events.NewValidator(events.ValidationConfig{ValidateToolCallArgsJSON: true})
+		dec := encoding.NewValidatingDecoder(json.NewJSONDecoder(nil), validator)
+
+		for _, data := range []string{
+			`{"type":"RUN_STARTED","threadId":"thread-1","runId":"run-1"}`,
+			`{"type":"TOOL_CALL_START","toolCallId":"tool-1","toolCallName":"search"}`,
+			`{"type":"TOOL_CALL_ARGS","toolCallId":"tool-1","delta":"{\"q\":"}`,
+		} {
+			_, err := dec.Decode(ctx, []byte(data))
+			require.NoError(t, err)
+		}
+
+		_, err := dec.Decode(ctx, []byte(`{"type":"TOOL_CALL_END","toolCallId":"tool-1"}`))
+		var argsErr *events.ToolCallArgsError
+		assert.True(t, errors.As(err, &argsErr))
+	})
+
+	t.Run("validates batches in order", func(t *testing.T) {
+		dec := encoding.NewValidatingDecoder(json.NewJSONDecoder(nil), nil)
+
+		decoded, err := dec.DecodeMultiple(ctx, []byte(`[
+			{"type":"RUN_STARTED","threadId":"thread-1","runId":"run-1"},
+			{"type":"RUN_FINISHED","threadId":"thread-1","runId":"run-1"}
+		]`))
+		require.NoError(t, err)
+		assert.Len(t, decoded, 2)
+
+		_, err = dec.DecodeMultiple(ctx, []byte(`[
+			{"type":"STEP_STARTED","stepName":"plan"},
+			{"type":"STEP_FINISHED","stepName":"other"}
+		]`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoded event 1 failed validation")
+	})
+}